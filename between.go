@@ -0,0 +1,171 @@
+package calver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Span is the distance between the periods two versions stand for
+type Span struct {
+	// From is when the period of the first version starts
+	From time.Time
+	// To is when the period of the second version starts
+	To time.Time
+	// Releases is how many periods of the format of the first version there
+	// are from one to the other, negative if the second comes first
+	Releases int
+}
+
+// Duration returns the time from the first period to the second one
+func (s Span) Duration() time.Duration {
+	return s.To.Sub(s.From)
+}
+
+// String returns the span in words, for instance `3 months, 2 releases`
+func (s Span) String() string {
+	from, to := s.From, s.To
+	if to.Before(from) {
+		from, to = to, from
+	}
+
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	if from.AddDate(0, months, 0).After(to) {
+		months--
+	}
+
+	days := 0
+	for !from.AddDate(0, months, days+1).After(to) {
+		days++
+	}
+	rest := to.Sub(from.AddDate(0, months, days))
+
+	var words []string
+	for _, u := range []struct {
+		n    int
+		unit string
+	}{
+		{months / 12, "year"},
+		{months % 12, "month"},
+		{days, "day"},
+		{int(rest / time.Hour), "hour"},
+		{int(rest % time.Hour / time.Minute), "minute"},
+		{int(rest % time.Minute / time.Second), "second"},
+	} {
+		if u.n > 0 {
+			words = append(words, plural(u.n, u.unit))
+		}
+	}
+
+	if len(words) == 0 {
+		words = append(words, plural(0, "day"))
+	}
+
+	releases := s.Releases
+	if releases < 0 {
+		releases = -releases
+	}
+
+	return fmt.Sprintf("%s, %s", strings.Join(words, ", "), plural(releases, "release"))
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// Distance returns the span between the periods two versions stand for, for
+// instance from 2021.01 to 2021.04 in `YYYY.0M` there are 3 months and 3
+// releases. The iterations are left out, and both versions need a year
+// segment, or custom ones with a range, to tell when they are. Formats without
+// a day are counted by walking through their versions a day at a time, which
+// takes longer the further apart the versions are
+func Distance(a, b *CalVer) (Span, error) {
+	from, _, err := a.dates()
+	if err != nil {
+		return Span{}, err
+	}
+
+	to, _, err := b.dates()
+	if err != nil {
+		return Span{}, err
+	}
+
+	s := Span{From: from[0], To: to[0]}
+
+	start, end, sign := s.From, s.To, 1
+	if end.Before(start) {
+		start, end, sign = end, start, -1
+	}
+
+	// formats down to the day or below have a release every step, so they're
+	// counted right away rather than one by one
+	if a.format.ordered() && a.format.has(segmentShortDay, segmentPaddedDay, segmentShortDayOfYear, segmentPaddedDayOfYear) {
+		s.Releases = int(end.Sub(start)/a.format.step()) * sign
+		return s, nil
+	}
+
+	// every version up to and including the one at the end is counted, but
+	// the one at the start is where it's counted from
+	it := a.versions(start, end.Add(time.Nanosecond))
+	for it.Next() {
+		s.Releases++
+	}
+
+	s.Releases = (s.Releases - 1) * sign
+
+	return s, nil
+}
+
+// Between returns the time and the number of releases from the period of the
+// first version to the period of the second one, see Distance. Both are zero
+// when the versions can't be told a date
+func Between(a, b *CalVer) (time.Duration, int) {
+	s, err := Distance(a, b)
+	if err != nil {
+		return 0, 0
+	}
+
+	return s.Duration(), s.Releases
+}
+
+// ordered tells if the versions of the format follow each other in time, one
+// period after another. That's the case when the format starts from the year
+// and every segment breaks down the one before it, unlike `MM.DD` that wraps
+// around at the end of the year or `YYYY.MM.HH` that goes through the same
+// hours every day
+func (f *format) ordered() bool {
+	var prev segment
+	for _, p := range f.parts() {
+		for _, s := range p {
+			g := s.granularity()
+			if g == 0 {
+				continue
+			}
+
+			switch {
+			case prev == segmentEmpty:
+				if g != 1 {
+					return false
+				}
+			case s == segmentShortDayOfYear || s == segmentPaddedDayOfYear:
+				if pg := prev.granularity(); pg != 1 && pg != 2 {
+					return false
+				}
+			case s == segmentShortDay || s == segmentPaddedDay:
+				if prev != segmentShortMonth && prev != segmentPaddedMonth {
+					return false
+				}
+			case g != prev.granularity()+1:
+				return false
+			}
+
+			prev = s
+		}
+	}
+
+	return prev != segmentEmpty
+}
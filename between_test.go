@@ -0,0 +1,111 @@
+package calver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBetween(t *testing.T) {
+	cases := []struct {
+		a, b, format string
+		releases     int
+		human        string
+	}{
+		{"2021.01", "2021.04", "YYYY.0M", 3, "3 months, 3 releases"},
+		{"2021.04-2", "2021.01", "YYYY.0M", -3, "3 months, 3 releases"},
+		{"2021.01", "2021.01-dev.4", "YYYY.0M", 0, "0 days, 0 releases"},
+		{"2020.3.5", "2021.4.6", "YYYY.MM.DD", 397, "1 year, 1 month, 1 day, 397 releases"},
+		{"2021.3.1", "2021.3.2", "YYYY.MM.DD", 1, "1 day, 1 release"},
+		{"2021.09", "2021.11", "YYYY.0W", 2, "14 days, 2 releases"},
+		{"2021.0305.14", "2021.0305.16", "YYYY.0M0D.0H", 2, "2 hours, 2 releases"},
+		{"2021.0101.000000", "2021.0401.000000", "YYYY.0M0D.0H0m0S", 7776000, "3 months, 7776000 releases"},
+		{"2021.0401.000000", "2021.0101.000000", "YYYY.0M0D.0H0m0S", -7776000, "3 months, 7776000 releases"},
+		{"2021.059", "2021.064", "YYYY.0DDD", 5, "5 days, 5 releases"},
+	}
+
+	for _, tc := range cases {
+		a, err := Parse(tc.a, tc.format, "dev")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", tc.a, err)
+		}
+
+		b, err := Parse(tc.b, tc.format, "dev")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", tc.b, err)
+		}
+
+		s, err := Distance(a, b)
+		if err != nil {
+			t.Errorf("failed to tell the distance from %s to %s: %s", tc.a, tc.b, err)
+			continue
+		}
+
+		if s.Releases != tc.releases {
+			t.Errorf("releases from %s to %s should be %d but they were %d", tc.a, tc.b, tc.releases, s.Releases)
+		}
+
+		if s.String() != tc.human {
+			t.Errorf("distance from %s to %s should be %q but it was %q", tc.a, tc.b, tc.human, s)
+		}
+
+		d, n := Between(a, b)
+		if d != s.Duration() || n != s.Releases {
+			t.Errorf("between should be %s and %d but it was %s and %d", s.Duration(), s.Releases, d, n)
+		}
+	}
+}
+
+func TestBetween_SixMonths(t *testing.T) {
+	a, _ := Parse("2020.10.1", "YYYY.MM.DD", "dev")
+	b, _ := Parse("2021.4.2", "YYYY.MM.DD", "dev")
+
+	s, _ := Distance(a, b)
+	if !s.From.AddDate(0, 6, 0).Before(s.To) {
+		t.Errorf("distance from %s to %s should be more than 6 months", a, b)
+	}
+
+	if d, _ := Between(a, b); d != 183*24*time.Hour {
+		t.Errorf("distance should be 183 days but it was %s", d)
+	}
+}
+
+func TestBetween_NoYear(t *testing.T) {
+	a, _ := Parse("3.5", "MM.DD", "dev")
+	b, _ := Parse("3.6", "MM.DD", "dev")
+
+	if _, err := Distance(a, b); err == nil {
+		t.Error("distance of a format without a year should not be told")
+	}
+
+	if d, n := Between(a, b); d != 0 || n != 0 {
+		t.Errorf("between should be zeros but it was %s and %d", d, n)
+	}
+}
+
+func TestFormat_Ordered(t *testing.T) {
+	for raw, expected := range map[string]bool{
+		"YYYY.MM.DD":       true,
+		"YY.0M.MICRO":      true,
+		"YYYY.DDD.0H":      true,
+		"YYYY.Q.DDD":       true,
+		"YYYY.0M0D.0H0m0S": true,
+		"YYYY.WW":          true,
+		"YYYY.0W.DDD":      true,
+		"YYYY.0W.0D":       false,
+		"MM.DD":            false,
+		"0H.0m.0S":         false,
+		"YYYY.DD.MM":       false,
+		"YYYY.Q.DD":        false,
+		"YYYY.MM.HH":       false,
+		"0M.0D.MICRO":      false,
+	} {
+		f, err := newFormat(raw)
+		if err != nil {
+			t.Fatalf("failed to parse format %s: %s", raw, err)
+		}
+
+		if actual := f.ordered(); actual != expected {
+			t.Errorf("order of %s should be %t but it was %t", raw, expected, actual)
+		}
+	}
+}
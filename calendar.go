@@ -0,0 +1,106 @@
+package calver
+
+import (
+	"fmt"
+	"time"
+)
+
+// Calendar tells how years are divided for the year, quarter, month and day
+// of year segments. The zero value is the Gregorian calendar, which is what
+// the other segments always follow
+type Calendar struct {
+	// Start is the month the year starts in, a year that doesn't start in
+	// January is named after the year it ends in, so with April as the start
+	// 2021.1 in `YYYY.MM` would be April of 2020 and 2021.10 January of 2021
+	Start time.Month
+	// Retail divides the year into whole weeks starting on the Monday
+	// nearest to the first of the start month, and each quarter into months
+	// of 4, 4 and 5 weeks. The extra week every few years goes to the last
+	// month of the year
+	Retail bool
+}
+
+// WithCalendar is an option to compute the year, quarter, month and day of
+// year segments relative to the provided calendar instead of the Gregorian one
+func WithCalendar(cal Calendar) Option {
+	return func(c *CalVer) error {
+		if cal.Start < 0 || cal.Start > time.December {
+			return fmt.Errorf("invalid month for the start of the year: %d", cal.Start)
+		}
+
+		c.format.calendar = cal
+		return nil
+	}
+}
+
+func (cal Calendar) month() time.Month {
+	if cal.Start == 0 {
+		return time.January
+	}
+
+	return cal.Start
+}
+
+// start returns the first day of the provided year
+func (cal Calendar) start(year int) time.Time {
+	// years that don't start in January are named after the year they end in
+	if cal.month() != time.January {
+		year--
+	}
+
+	t := time.Date(year, cal.month(), 1, 0, 0, 0, 0, time.UTC)
+	if !cal.Retail {
+		return t
+	}
+
+	// the Monday nearest to the first of the month, which is at most three
+	// days away from it
+	offset := (int(time.Monday) - int(t.Weekday()) + 7) % 7
+	if offset > 3 {
+		offset -= 7
+	}
+
+	return t.AddDate(0, 0, offset)
+}
+
+// date returns the year, month and day of the year the provided time falls
+// in for the calendar
+func (cal Calendar) date(t time.Time) (int, int, int) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	year := t.Year()
+	if cal.month() != time.January && t.Month() >= cal.month() {
+		year++
+	}
+
+	// retail years could start a few days before or after the first of the
+	// month so the guess could be one year off
+	if day.Before(cal.start(year)) {
+		year--
+	} else if !day.Before(cal.start(year + 1)) {
+		year++
+	}
+
+	days := int(day.Sub(cal.start(year)).Hours()/24) + 1
+
+	if !cal.Retail {
+		month := (int(t.Month())-int(cal.month())+12)%12 + 1
+		return year, month, days
+	}
+
+	week := (days - 1) / 7
+	quarter := week / 13
+	if quarter > 3 {
+		quarter = 3
+	}
+
+	month := quarter*3 + 1
+	switch w := week - quarter*13; {
+	case w >= 8:
+		month += 2
+	case w >= 4:
+		month++
+	}
+
+	return year, month, days
+}
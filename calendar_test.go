@@ -0,0 +1,91 @@
+package calver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithCalendar(t *testing.T) {
+	cases := []struct {
+		now      time.Time
+		cal      Calendar
+		format   string
+		expected string
+	}{
+		{time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC), Calendar{}, "YYYY.MM.DD", "2021.5.10"},
+		{time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC), Calendar{Start: time.April}, "YYYY.MM.DD", "2022.2.10"},
+		{time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC), Calendar{Start: time.April}, "YY.Q", "22.1"},
+		{time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC), Calendar{Start: time.April}, "YYYY.0M", "2021.12"},
+		{time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC), Calendar{Start: time.April}, "YYYY.DDD", "2022.1"},
+		{time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), Calendar{Start: time.October}, "YYYY.Q.MM", "2022.1.1"},
+		{time.Date(2021, 9, 30, 0, 0, 0, 0, time.UTC), Calendar{Start: time.October}, "YYYY.Q.MM", "2021.4.12"},
+		{time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC), Calendar{Retail: true}, "YYYY.MM.DDD", "2026.1.1"},
+		{time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC), Calendar{Retail: true}, "YYYY.MM", "2026.2"},
+		{time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC), Calendar{Retail: true}, "YYYY.MM", "2026.3"},
+		{time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC), Calendar{Retail: true}, "YYYY.Q.MM", "2026.2.4"},
+		{time.Date(2026, 12, 27, 0, 0, 0, 0, time.UTC), Calendar{Retail: true}, "YYYY.MM.DDD", "2026.12.364"},
+		{time.Date(2026, 12, 28, 0, 0, 0, 0, time.UTC), Calendar{Retail: true}, "YYYY.MM.DDD", "2026.12.365"},
+		{time.Date(2027, 1, 3, 0, 0, 0, 0, time.UTC), Calendar{Retail: true}, "YYYY.MM.DDD", "2026.12.371"},
+		{time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC), Calendar{Retail: true}, "YYYY.MM.DDD", "2027.1.1"},
+		{time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), Calendar{Start: time.February, Retail: true}, "YYYY.MM", "2022.1"},
+	}
+
+	for _, tc := range cases {
+		reset := mockNowFunc(func() time.Time {
+			return tc.now
+		})
+
+		c, err := New(tc.format, "", WithCalendar(tc.cal))
+		if err != nil {
+			t.Fatalf("failed to create a version: %s", err)
+		}
+
+		if r := c.Release(); r != tc.expected {
+			t.Errorf("release version on %s should be %s but it was %s", tc.now.Format("2006-01-02"), tc.expected, r)
+		}
+
+		reset()
+	}
+
+	if _, err := New("YYYY.MM", "", WithCalendar(Calendar{Start: 13})); err == nil {
+		t.Error("calendar should not start in an invalid month")
+	}
+}
+
+func TestWithCalendar_Parse(t *testing.T) {
+	fiscal := WithCalendar(Calendar{Start: time.April})
+
+	c, err := Parse("2021.366", "YYYY.DDD", "", fiscal)
+	if err == nil {
+		t.Errorf("fiscal year 2021 should not have a 366th day but got %s", c)
+	}
+
+	c, err = Parse("2024.366", "YYYY.DDD", "", fiscal)
+	if err != nil {
+		t.Fatalf("fiscal year 2024 should have a 366th day: %s", err)
+	}
+
+	n, err := c.Convert("YYYY.0M.0D")
+	if err != nil {
+		t.Fatalf("failed to convert %s: %s", c, err)
+	}
+
+	// fiscal year 2024 ends on 31st of March, 2024
+	if n.String() != "2024.12.31" {
+		t.Errorf("converted version of %s should be 2024.12.31 but it was %s", c, n)
+	}
+
+	retail := WithCalendar(Calendar{Retail: true})
+	if _, err := Parse("2026.371", "YYYY.DDD", "", retail); err != nil {
+		t.Errorf("retail year 2026 should have 53 weeks: %s", err)
+	}
+
+	if _, err := Parse("2025.365", "YYYY.DDD", "", retail); err == nil {
+		t.Error("retail year 2025 should only have 52 weeks")
+	}
+
+	m, _ := Parse("2022.1", "YYYY.MM", "", fiscal)
+	if _, err := m.Convert("YYYY.Q"); err != nil {
+		t.Errorf("failed to convert %s: %s", m, err)
+	}
+}
@@ -26,6 +26,28 @@ const (
 	ShortDay = "DD"
 	// PaddedDay notation for CalVer - 01, 02 ... 30, 31
 	PaddedDay = "0D"
+	// ShortHour notation for CalVer - 0, 1 ... 22, 23
+	ShortHour = "HH"
+	// PaddedHour notation for CalVer - 00, 01 ... 22, 23
+	PaddedHour = "0H"
+	// ShortMinute notation for CalVer - 0, 1 ... 58, 59
+	ShortMinute = "mm"
+	// PaddedMinute notation for CalVer - 00, 01 ... 58, 59
+	PaddedMinute = "0m"
+	// ShortSecond notation for CalVer - 0, 1 ... 58, 59
+	ShortSecond = "SS"
+	// PaddedSecond notation for CalVer - 00, 01 ... 58, 59
+	PaddedSecond = "0S"
+	// ShortDayOfYear notation for CalVer - 1, 2 ... 365, 366
+	ShortDayOfYear = "DDD"
+	// PaddedDayOfYear notation for CalVer - 001, 002 ... 365, 366
+	PaddedDayOfYear = "0DDD"
+	// Quarter notation for CalVer - 1, 2, 3, 4
+	Quarter = "Q"
+	// Micro notation for CalVer - 0, 1, 2 ... it holds the iteration within
+	// the period of the other segments instead of the `-N` suffix, so it
+	// could only be the last part of the format
+	Micro = "MICRO"
 )
 
 type segment int
@@ -41,6 +63,18 @@ const (
 	segmentPaddedWeek
 	segmentShortDay
 	segmentPaddedDay
+	segmentShortHour
+	segmentPaddedHour
+	segmentShortMinute
+	segmentPaddedMinute
+	segmentShortSecond
+	segmentPaddedSecond
+	segmentShortDayOfYear
+	segmentPaddedDayOfYear
+	segmentQuarter
+	segmentMicro
+	// custom segments registered with RegisterSegment start from here
+	segmentCustom
 )
 
 func (s segment) String() string {
@@ -63,9 +97,32 @@ func (s segment) String() string {
 		return ShortDay
 	case segmentPaddedDay:
 		return PaddedDay
+	case segmentShortHour:
+		return ShortHour
+	case segmentPaddedHour:
+		return PaddedHour
+	case segmentShortMinute:
+		return ShortMinute
+	case segmentPaddedMinute:
+		return PaddedMinute
+	case segmentShortSecond:
+		return ShortSecond
+	case segmentPaddedSecond:
+		return PaddedSecond
+	case segmentShortDayOfYear:
+		return ShortDayOfYear
+	case segmentPaddedDayOfYear:
+		return PaddedDayOfYear
+	case segmentQuarter:
+		return Quarter
+	case segmentMicro:
+		return Micro
 	case segmentEmpty:
 		return ""
 	default:
+		if p := s.provider(); p != nil {
+			return p.Name()
+		}
 		panic("invalid format segment")
 	}
 }
@@ -74,8 +131,6 @@ func (s segment) pattern() string {
 	switch s {
 	case segmentFullYear:
 		return "2006"
-	case segmentPaddedYear:
-		return "06"
 	case segmentShortMonth:
 		return "1"
 	case segmentPaddedMonth:
@@ -89,9 +144,16 @@ func (s segment) pattern() string {
 	}
 }
 
-func (s segment) conv(t time.Time) string {
+func (s segment) conv(t time.Time, cal Calendar) string {
+	if p := s.provider(); p != nil {
+		return p.Render(Context{Time: t, Calendar: cal})
+	}
+
+	year, month, day := cal.date(t)
+
 	switch s {
-	case segmentEmpty:
+	case segmentEmpty, segmentMicro:
+		// the micro is rendered out of the iteration rather than the time
 		return ""
 	case segmentShortWeek:
 		_, w := t.ISOWeek()
@@ -99,20 +161,74 @@ func (s segment) conv(t time.Time) string {
 	case segmentPaddedWeek:
 		_, w := t.ISOWeek()
 		return fmt.Sprintf("%02d", w)
-	case segmentShortYear:
-		y := t.Format("06")
-		if strings.HasPrefix(y, "0") {
-			return strings.TrimPrefix(y, "0")
-		}
-		return y
+	case segmentFullYear:
+		return fmt.Sprintf("%04d", year)
+	case segmentShortYear, segmentPaddedYear:
+		// short years are counted from 2000 rather than wrapping around every
+		// century, so 2106 is 106
+		return s.itoa(year - 2000)
+	case segmentShortMonth, segmentPaddedMonth:
+		return s.itoa(month)
+	case segmentShortHour, segmentPaddedHour:
+		return s.itoa(t.Hour())
+	case segmentShortMinute, segmentPaddedMinute:
+		return s.itoa(t.Minute())
+	case segmentShortSecond, segmentPaddedSecond:
+		return s.itoa(t.Second())
+	case segmentShortDayOfYear, segmentPaddedDayOfYear:
+		return s.itoa(day)
+	case segmentQuarter:
+		return s.itoa((month-1)/3 + 1)
 	}
 
 	return t.Format(s.pattern())
 }
 
+// clock tells if the segment stands for a time of the day rather than a date
+func (s segment) clock() bool {
+	return s >= segmentShortHour && s <= segmentPaddedSecond
+}
+
+// fixed tells if the segment always renders the same number of digits, which
+// is required for all but the last segment of a part to tell them apart
+func (s segment) fixed() bool {
+	switch s {
+	case segmentFullYear, segmentPaddedMonth, segmentPaddedWeek, segmentPaddedDay,
+		segmentPaddedHour, segmentPaddedMinute, segmentPaddedSecond,
+		segmentPaddedDayOfYear, segmentQuarter:
+		return true
+	default:
+		return false
+	}
+}
+
+// width returns the number of digits the segment takes at most
+func (s segment) width() int {
+	switch s {
+	case segmentFullYear:
+		return 4
+	case segmentShortYear, segmentPaddedYear, segmentShortDayOfYear, segmentPaddedDayOfYear:
+		return 3
+	case segmentQuarter:
+		return 1
+	case segmentEmpty, segmentMicro:
+		return 0
+	default:
+		return 2
+	}
+}
+
 func (s segment) parse(raw string) (string, error) {
 	errBadSegment := fmt.Errorf("provided string doesn't match the format segment: %s", s.String())
 
+	if p := s.provider(); p != nil {
+		v, err := p.Parse(raw)
+		if err != nil {
+			return "", errBadSegment
+		}
+		return v, nil
+	}
+
 	switch {
 	case s == segmentEmpty && raw == "":
 		return "", nil
@@ -123,16 +239,47 @@ func (s segment) parse(raw string) (string, error) {
 		if err != nil {
 			return "", errBadSegment
 		}
-		if w < 0 || w > 52 {
+		if w < 1 || w > 53 {
 			return "", errBadSegment
 		}
 
 		return raw, nil
-	case s == segmentShortYear:
-		_, err := strconv.Atoi(raw)
-		if err != nil {
+	case s == segmentShortYear || s == segmentPaddedYear:
+		// years before 2000 can't be told with a short year
+		y, err := strconv.Atoi(raw)
+		if err != nil || y < 0 || y > 9999-2000 || s.itoa(y) != raw {
+			return "", errBadSegment
+		}
+		return raw, nil
+	case s.clock():
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 0 || s.itoa(v) != raw {
+			return "", errBadSegment
+		}
+		if (s == segmentShortHour || s == segmentPaddedHour) && v > 23 || v > 59 {
 			return "", errBadSegment
 		}
+
+		return raw, nil
+	case s == segmentShortDayOfYear || s == segmentPaddedDayOfYear || s == segmentQuarter:
+		v, err := strconv.Atoi(raw)
+		if err != nil || s.itoa(v) != raw {
+			return "", errBadSegment
+		}
+		// how many days there are depends on the year and the calendar, up to
+		// 53 whole weeks for retail ones, which is checked along with the rest
+		// of the segments by the format
+		if v < 1 || (s == segmentQuarter && v > 4) || v > 371 {
+			return "", errBadSegment
+		}
+
+		return raw, nil
+	case s == segmentMicro:
+		v, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || strconv.FormatUint(v, 10) != raw {
+			return "", errBadSegment
+		}
+
 		return raw, nil
 	}
 
@@ -143,6 +290,20 @@ func (s segment) parse(raw string) (string, error) {
 	return t.Format(s.pattern()), nil
 }
 
+// itoa renders a plain number the way the segment would, i.e. with a leading
+// zero for padded segments
+func (s segment) itoa(n int) string {
+	switch s {
+	case segmentPaddedYear, segmentPaddedMonth, segmentPaddedWeek, segmentPaddedDay,
+		segmentPaddedHour, segmentPaddedMinute, segmentPaddedSecond:
+		return fmt.Sprintf("%02d", n)
+	case segmentPaddedDayOfYear:
+		return fmt.Sprintf("%03d", n)
+	}
+
+	return strconv.Itoa(n)
+}
+
 func newSegment(s string) (segment, error) {
 	switch s {
 	case FullYear:
@@ -163,15 +324,222 @@ func newSegment(s string) (segment, error) {
 		return segmentShortDay, nil
 	case PaddedDay:
 		return segmentPaddedDay, nil
+	case ShortHour:
+		return segmentShortHour, nil
+	case PaddedHour:
+		return segmentPaddedHour, nil
+	case ShortMinute:
+		return segmentShortMinute, nil
+	case PaddedMinute:
+		return segmentPaddedMinute, nil
+	case ShortSecond:
+		return segmentShortSecond, nil
+	case PaddedSecond:
+		return segmentPaddedSecond, nil
+	case ShortDayOfYear:
+		return segmentShortDayOfYear, nil
+	case PaddedDayOfYear:
+		return segmentPaddedDayOfYear, nil
+	case Quarter:
+		return segmentQuarter, nil
+	case Micro:
+		return segmentMicro, nil
 	default:
+		if seg, ok := customSegment(s); ok {
+			return seg, nil
+		}
 		return segment(0), fmt.Errorf("invalid format segment: %s", s)
 	}
 }
 
+// part is a dot separated part of the format. It's usually a single segment
+// but could be a few of them put together, for instance `0H0m`
+type part []segment
+
+func (p part) String() string {
+	v := ""
+	for _, s := range p {
+		v += s.String()
+	}
+
+	return v
+}
+
+func (p part) conv(t time.Time, cal Calendar) string {
+	v := ""
+	for _, s := range p {
+		v += s.conv(t, cal)
+	}
+
+	return v
+}
+
+// split breaks the raw string into the values of each segment of the part
+func (p part) split(raw string) ([]string, error) {
+	vals := make([]string, len(p))
+	for i, s := range p {
+		w := len(raw)
+		if i < len(p)-1 {
+			w = s.width()
+		}
+
+		if w > len(raw) {
+			return nil, fmt.Errorf("provided string doesn't match the format segment: %s", s.String())
+		}
+
+		vals[i], raw = raw[:w], raw[w:]
+	}
+
+	return vals, nil
+}
+
+func (p part) parse(raw string) (string, error) {
+	if len(p) == 0 {
+		return segmentEmpty.parse(raw)
+	}
+
+	vals, err := p.split(raw)
+	if err != nil {
+		return "", err
+	}
+
+	v := ""
+	for i, s := range p {
+		val, err := s.parse(vals[i])
+		if err != nil {
+			return "", err
+		}
+
+		v += val
+	}
+
+	return v, nil
+}
+
+// itoa renders a plain number the way the part would
+func (p part) itoa(n int) string {
+	if len(p) == 1 {
+		return p[0].itoa(n)
+	}
+
+	return fmt.Sprintf("%0*d", p.width(), n)
+}
+
+// width returns the number of digits the part takes at most
+func (p part) width() int {
+	w := 0
+	for _, s := range p {
+		w += s.width()
+	}
+
+	return w
+}
+
+func newPart(raw string) (part, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("invalid format segment: %s", raw)
+	}
+
+	var p part
+	for rest := raw; rest != ""; {
+		// the longest token wins so that `YYYY` isn't taken for `YY` twice
+		token := ""
+		for _, v := range append(valid, customTokens()...) {
+			if strings.HasPrefix(rest, v) && len(v) > len(token) {
+				token = v
+			}
+		}
+
+		if token == "" {
+			return nil, fmt.Errorf("invalid format segment: %s", raw)
+		}
+
+		s, err := newSegment(token)
+		if err != nil {
+			return nil, err
+		}
+
+		p = append(p, s)
+		rest = strings.TrimPrefix(rest, token)
+	}
+
+	for _, s := range p[:len(p)-1] {
+		if !s.fixed() {
+			return nil, fmt.Errorf("only the last segment of a part could have a variable width: %s", raw)
+		}
+	}
+
+	return p, nil
+}
+
 type format struct {
-	major segment
-	minor segment
-	micro segment
+	major    part
+	minor    part
+	micro    part
+	calendar Calendar
+}
+
+// conv returns the version the format stands for at the provided time
+func (f format) conv(t time.Time) version {
+	return newVersion(f.major.conv(t, f.calendar), f.minor.conv(t, f.calendar), f.micro.conv(t, f.calendar))
+}
+
+// renders returns an error if the format can't render the time in a way that
+// could be parsed back, which is the case for short years before 2000 and for
+// custom segments with a range that doesn't cover the time, like a release
+// train before its epoch
+func (f format) renders(t time.Time) error {
+	if year, _, _ := f.calendar.date(t); year < 2000 && f.has(segmentShortYear, segmentPaddedYear) {
+		return fmt.Errorf("years before 2000 can't be told with a short year: %d", year)
+	}
+
+	// ranges are told in UTC like the dates of the other segments
+	day := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	for _, p := range f.parts() {
+		for _, s := range p {
+			r, ok := s.provider().(SegmentRange)
+			if !ok {
+				continue
+			}
+
+			v := s.conv(t, f.calendar)
+			if from, to, err := r.Range(v); err != nil || day.Before(from) || !day.Before(to) {
+				return fmt.Errorf("time isn't in the period of the segment it renders: %s", s)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f format) parts() []part {
+	return []part{f.major, f.minor, f.micro}
+}
+
+// has tells if any of the provided segments is a part of the format
+func (f format) has(segs ...segment) bool {
+	for _, p := range f.parts() {
+		for _, s := range p {
+			for _, seg := range segs {
+				if s == seg {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// counter tells if the last part of the format is micro, meaning that the
+// iteration is a part of the version rather than a suffix
+func (f format) counter() bool {
+	last := f.minor
+	if len(f.micro) > 0 {
+		last = f.micro
+	}
+
+	return len(last) == 1 && last[0] == segmentMicro
 }
 
 func (f format) parse(raw string) (string, string, string, error) {
@@ -195,28 +563,51 @@ func (f format) parse(raw string) (string, string, string, error) {
 		}
 	}
 
+	// only retail years could have more than 366 days
+	if f.calendar.Retail {
+		return major, minor, micro, nil
+	}
+
+	for i, raw := range []string{major, minor, micro} {
+		p := f.parts()[i]
+		vals, err := p.split(raw)
+		if err != nil || len(p) == 0 {
+			continue
+		}
+
+		for j, s := range p {
+			if s != segmentShortDayOfYear && s != segmentPaddedDayOfYear {
+				continue
+			}
+
+			if d, _ := strconv.Atoi(vals[j]); d > 366 {
+				return "", "", "", fmt.Errorf("provided string doesn't match the format segment: %s", s.String())
+			}
+		}
+	}
+
 	return major, minor, micro, nil
 }
 
 func (f format) String() string {
 	v := ""
 
-	if f.major != segmentEmpty {
+	if len(f.major) > 0 {
 		v += f.major.String()
 	}
 
-	if f.minor != segmentEmpty {
+	if len(f.minor) > 0 {
 		v += fmt.Sprintf(".%s", f.minor.String())
 	}
 
-	if f.micro != segmentEmpty {
+	if len(f.micro) > 0 {
 		v += fmt.Sprintf(".%s", f.micro.String())
 	}
 
 	return v
 }
 
-var valid = [9]string{
+var valid = []string{
 	FullYear,
 	ShortYear,
 	PaddedYear,
@@ -226,6 +617,16 @@ var valid = [9]string{
 	PaddedWeek,
 	ShortDay,
 	PaddedDay,
+	ShortHour,
+	PaddedHour,
+	ShortMinute,
+	PaddedMinute,
+	ShortSecond,
+	PaddedSecond,
+	ShortDayOfYear,
+	PaddedDayOfYear,
+	Quarter,
+	Micro,
 }
 
 func newFormat(raw string) (*format, error) {
@@ -240,38 +641,40 @@ func newFormat(raw string) (*format, error) {
 	}
 
 	for _, p := range parts {
-		isValid := false
-		for _, v := range valid {
-			if v == p {
-				isValid = true
-			}
-		}
-
-		if !isValid {
+		if _, err := newPart(p); err != nil {
 			return nil, fmt.Errorf("unsupported format: %s", raw)
 		}
 	}
 
-	major, err := newSegment(parts[0])
+	major, err := newPart(parts[0])
 	if err != nil {
 		return nil, fmt.Errorf("invalid format segment: %s", parts[0])
 	}
 
-	minor, err := newSegment(parts[1])
+	minor, err := newPart(parts[1])
 	if err != nil {
 		return nil, fmt.Errorf("invalid format segment: %s", parts[1])
 	}
 
-	if len(parts) == 2 {
-		return &format{major, minor, segmentEmpty}, nil
+	f := &format{major: major, minor: minor}
+
+	if len(parts) == 3 {
+		f.micro, err = newPart(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid format segment: %s", parts[2])
+		}
 	}
 
-	micro, err := newSegment(parts[2])
-	if err != nil {
-		return nil, fmt.Errorf("invalid format segment: %s", parts[2])
+	// micro holds the iteration so it has to be on its own in the last part
+	for i, p := range f.parts() {
+		for _, s := range p {
+			if s == segmentMicro && (len(p) > 1 || i != len(parts)-1) {
+				return nil, fmt.Errorf("%s could only be the last part of the format: %s", Micro, raw)
+			}
+		}
 	}
 
-	return &format{major, minor, micro}, nil
+	return f, nil
 }
 
 type version [3]string
@@ -304,28 +707,43 @@ type CalVer struct {
 	pre       bool
 	format    *format
 	version   version
+	iter      Iteration
+	monotonic Monotonic
+	strict    bool
+	err       error
 }
 
 // this is for testing purpose only
 var now = time.Now
 
-func (c *CalVer) next(pre bool) (string, string, string, uint64) {
-	t := now()
+func (c *CalVer) next(t time.Time, pre bool) (string, string, string, uint64, error) {
+	if err := c.format.renders(t); err != nil {
+		return "", "", "", 0, err
+	}
+
+	v := c.format.conv(t)
 
-	v := newVersion(c.format.major.conv(t), c.format.minor.conv(t), c.format.micro.conv(t))
+	if c.major != "" && !v.eq(c.version) && c.behind(t) {
+		switch c.monotonic {
+		case MonotonicRefuse:
+			return "", "", "", 0, fmt.Errorf("next version would come before the current one: %s", c)
+		case MonotonicKeep:
+			v = c.version
+		}
+	}
 
 	if v.eq(c.version) {
 		if !pre && c.pre {
-			return c.major, c.minor, c.micro, c.increment
+			return c.major, c.minor, c.micro, c.increment, nil
 		}
 
-		return c.major, c.minor, c.micro, c.increment + 1
+		return c.major, c.minor, c.micro, c.increment + 1, nil
 	}
 
 	c.version = v
 
 	major, minor, micro := v.spread()
-	return major, minor, micro, 0
+	return major, minor, micro, 0, nil
 }
 
 // Release generates new release version and returns the string.
@@ -337,10 +755,22 @@ func (c *CalVer) next(pre bool) (string, string, string, uint64) {
 //		[..]
 //		2020.12.12-999	->	2020.12.12-1000
 // Furthermore, if the previous version was a prerelease with an iteration
-// then it will remove the prerelease modifier and keep the same version
+// then it will remove the prerelease modifier and keep the same version.
+// In case the clock is behind the previous version, it's left as it is and
+// Err tells why, unless another Monotonic policy is provided. Err needs to be
+// checked before publishing the version, or Next used instead
 func (c *CalVer) Release() string {
-	c.major, c.minor, c.micro, c.increment = c.next(false)
+	return c.release(now())
+}
 
+// release generates the release version for the provided time
+func (c *CalVer) release(t time.Time) string {
+	major, minor, micro, inc, err := c.next(t, false)
+	if c.err = err; err != nil {
+		return c.String()
+	}
+
+	c.major, c.minor, c.micro, c.increment = major, minor, micro, inc
 	c.pre = false
 
 	return c.String()
@@ -348,14 +778,77 @@ func (c *CalVer) Release() string {
 
 // PreRelease generates new prerelease version and returns the string.
 // It works same as Release but it suffixes each version with the provided
-// `modifier`
+// `modifier`, and Err needs to be checked the same way
 func (c *CalVer) PreRelease() string {
-	c.major, c.minor, c.micro, c.increment = c.next(true)
+	major, minor, micro, inc, err := c.next(now(), true)
+	if c.err = err; err != nil {
+		return c.String()
+	}
+
+	c.major, c.minor, c.micro, c.increment = major, minor, micro, inc
 	c.pre = true
 
 	return c.String()
 }
 
+// Next generates the next release version, or prerelease with pre, the same
+// way Release and PreRelease do, but returns the error along with it rather
+// than leaving it for Err, for instance:
+//		tag, err := c.Next(false)
+//		if err != nil {
+//			// the clock is behind the previous version
+//		}
+func (c *CalVer) Next(pre bool) (string, error) {
+	if pre {
+		v := c.PreRelease()
+		return v, c.err
+	}
+
+	v := c.Release()
+	return v, c.err
+}
+
+// core returns the dotted version without any iteration or modifier, unless
+// the format has a micro for the iteration
+func (c *CalVer) core() string {
+	v := ""
+
+	if c.major == "" {
+		return v
+	}
+
+	v += c.major
+
+	minor, micro := c.minor, c.micro
+	if c.format.counter() {
+		if len(c.format.micro) > 0 {
+			micro = strconv.FormatUint(c.increment, 10)
+		} else {
+			minor = strconv.FormatUint(c.increment, 10)
+		}
+	}
+
+	if minor != "" {
+		v += fmt.Sprintf(".%s", minor)
+	}
+
+	if micro != "" {
+		v += fmt.Sprintf(".%s", micro)
+	}
+
+	return v
+}
+
+// iteration returns the iteration that goes after the version, which is
+// always zero when the format has a micro for it
+func (c *CalVer) iteration() uint64 {
+	if c.format.counter() {
+		return 0
+	}
+
+	return c.increment
+}
+
 func (c *CalVer) String() string {
 	v := ""
 
@@ -365,36 +858,72 @@ func (c *CalVer) String() string {
 		v += c.format.String()
 	}
 
-	if c.major != "" {
-		v += c.major
-	}
+	v += c.core()
 
-	if c.minor != "" {
-		v += fmt.Sprintf(".%s", c.minor)
+	if c.pre {
+		v += fmt.Sprintf("-%s", c.modifier)
 	}
 
-	if c.micro != "" {
-		v += fmt.Sprintf(".%s", c.micro)
+	if c.iteration() > 0 || (c.pre && c.iter.Zero && !c.format.counter()) {
+		v += c.iter.render(c.iteration(), c.pre)
 	}
 
-	if c.pre {
-		v += fmt.Sprintf("-%s", c.modifier)
+	return v
+}
+
+// Major returns the major segment of the version, which is empty when there
+// hasn't been any release yet
+func (c *CalVer) Major() string {
+	return c.major
+}
+
+// Minor returns the minor segment of the version, or the counter when the
+// format has a micro for it
+func (c *CalVer) Minor() string {
+	if c.format.counter() && len(c.format.micro) == 0 && c.major != "" {
+		return strconv.FormatUint(c.increment, 10)
 	}
 
-	if c.increment > 0 {
-		if c.pre {
-			v += fmt.Sprintf(".%d", c.increment)
-		} else {
-			v += fmt.Sprintf("-%d", c.increment)
-		}
+	return c.minor
+}
+
+// Micro returns the micro segment of the version, or the counter when the
+// format has a micro for it
+func (c *CalVer) Micro() string {
+	if c.format.counter() && len(c.format.micro) > 0 && c.major != "" {
+		return strconv.FormatUint(c.increment, 10)
 	}
 
-	return v
+	return c.micro
+}
+
+// Iteration returns the iteration that goes after the version, which is
+// always zero when the format has a micro for it
+func (c *CalVer) Iteration() uint64 {
+	return c.iteration()
+}
+
+// Modifier returns the modifier for prereleases
+func (c *CalVer) Modifier() string {
+	return c.modifier
+}
+
+// IsPreRelease tells if the version is a prerelease
+func (c *CalVer) IsPreRelease() bool {
+	return c.pre
+}
+
+// Format returns the format of the version
+func (c *CalVer) Format() Format {
+	return Format(c.format.String())
 }
 
+// Option changes how a CalVer generates and parses versions
+type Option func(*CalVer) error
+
 // New creates a new instance of CalVer using the provided format and modifier
 // which defaults to `dev`
-func New(format, modifier string) (*CalVer, error) {
+func New(format, modifier string, opts ...Option) (*CalVer, error) {
 	if modifier == "" {
 		modifier = "dev"
 	}
@@ -404,50 +933,89 @@ func New(format, modifier string) (*CalVer, error) {
 		return nil, err
 	}
 
-	return &CalVer{modifier: modifier, format: f}, nil
+	c := &CalVer{modifier: modifier, format: f}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
 // Parse takes raw version, tries to parse it into provided format and returns
 // the CalVer instance. It takes a modifier as well which default to `dev`
-func Parse(raw, format, modifier string) (*CalVer, error) {
-	c, err := New(format, modifier)
+func Parse(raw, format, modifier string, opts ...Option) (*CalVer, error) {
+	c, err := New(format, modifier, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	parts := strings.Split(raw, "-")
-	if len(parts) > 1 {
-		// meaning that it could either be an iterative build or a prerelease
-		var i string
-		if strings.Contains(raw, c.modifier) {
-			c.pre = true
-			if strings.Contains(parts[1], ".") {
-				i = strings.Split(parts[1], ".")[1]
-			} else {
-				i = "0"
+	errBadVersion := fmt.Errorf("provided string doesn't match the format: %s", c.format)
+
+	// it could either be an iterative build or a prerelease, and when the
+	// iteration is already a part of the version there could only be the
+	// modifier after it
+	v, i, sep := raw, "", false
+	if idx := strings.Index(raw, fmt.Sprintf("-%s", c.modifier)); idx >= 0 {
+		c.pre = true
+		v, i = raw[:idx], raw[idx+len(c.modifier)+1:]
+
+		if i != "" {
+			if !strings.HasPrefix(i, ".") || c.format.counter() {
+				return nil, errBadVersion
 			}
-		} else {
-			i = parts[1]
+			i, sep = strings.TrimPrefix(i, "."), true
 		}
+	} else if !c.format.counter() {
+		v, i, sep = c.iter.split(raw, strings.Count(c.format.String(), ".")+1)
+	}
+
+	// a separator needs an iteration after it
+	if sep && i == "" {
+		return nil, errBadVersion
+	}
 
-		inc, err := strconv.ParseUint(i, 0, 64)
+	if i != "" {
+		inc, err := strconv.ParseUint(i, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("provided string doesn't match the format: %s", c.format)
+			return nil, errBadVersion
 		}
 
 		c.increment = inc
 	}
 
+	if err := c.set(v); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// set parses the dotted version part, without any iteration or modifier, into
+// the major, minor and micro segments of the CalVer
+func (c *CalVer) set(raw string) error {
 	// for now I'm only checking for `.` to verify if the format is valid which barely
 	// tells anything so this would be something I need to address later
 	count := strings.Count(c.format.String(), ".")
-	if strings.Count(parts[0], ".") != count {
-		return nil, fmt.Errorf("provided string doesn't match the format: %s", c.format)
+	if strings.Count(raw, ".") != count {
+		return fmt.Errorf("provided string doesn't match the format: %s", c.format)
 	}
 
-	major, minor, micro, err := c.format.parse(parts[0])
+	major, minor, micro, err := c.format.parse(raw)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if c.format.counter() {
+		segs := strings.Split(raw, ".")
+		c.increment, _ = strconv.ParseUint(segs[len(segs)-1], 10, 64)
+
+		if len(c.format.micro) > 0 {
+			micro = ""
+		} else {
+			minor = ""
+		}
 	}
 
 	c.major = major
@@ -456,5 +1024,18 @@ func Parse(raw, format, modifier string) (*CalVer, error) {
 
 	c.version = newVersion(major, minor, micro)
 
-	return c, nil
+	// how many days a year has depends on the year itself and the calendar,
+	// which can only be told once the year is known
+	year := c.format.has(segmentFullYear, segmentShortYear, segmentPaddedYear)
+	if year && c.format.has(segmentShortDayOfYear, segmentPaddedDayOfYear) {
+		if _, _, err := c.dates(); err != nil {
+			return fmt.Errorf("provided string doesn't match the format: %s", c.format)
+		}
+	}
+
+	if c.strict {
+		return c.check()
+	}
+
+	return nil
 }
@@ -7,9 +7,10 @@ import (
 )
 
 func mockNowFunc(fn func() time.Time) func() {
+	prev := now
 	now = fn
 	return func() {
-		now = time.Now
+		now = prev
 	}
 }
 
@@ -1146,3 +1147,352 @@ func TestCalVer_Release(t *testing.T) {
 		t.Errorf("prerelease version should be %s but it was %s", v0, r5)
 	}
 }
+
+func TestNew_YYYY0M0D0H0m(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2007, 2, 5, 14, 5, 9, 0, time.UTC)
+	})
+	defer reset()
+
+	c, err := New("YYYY.0M0D.0H0m", "")
+	if err != nil {
+		t.Fatalf("failed to create a version: %s", err)
+	}
+
+	if c.String() != "YYYY.0M0D.0H0m" {
+		t.Error("empty version doesn't return the format")
+	}
+
+	const (
+		v0 = "2007.0205.1405"
+		v1 = "2007.0205.1405-dev.1"
+		v2 = "2007.0205.1405-1"
+		v3 = "2007.0205.1406"
+	)
+
+	r0 := c.Release()
+	if r0 != v0 {
+		t.Errorf("release version should be %s but it was %s", v0, r0)
+	}
+
+	r1 := c.PreRelease()
+	if r1 != v1 {
+		t.Errorf("prerelease version should be %s but it was %s", v1, r1)
+	}
+
+	r2 := c.Release()
+	if r2 != v2 {
+		t.Errorf("release version should be %s but it was %s", v2, r2)
+	}
+
+	now = func() time.Time {
+		return time.Date(2007, 2, 5, 14, 6, 0, 0, time.UTC)
+	}
+
+	r3 := c.Release()
+	if r3 != v3 {
+		t.Errorf("release version should be %s but it was %s", v3, r3)
+	}
+}
+
+func TestNew_HHmmSS(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2007, 2, 5, 4, 5, 9, 0, time.UTC)
+	})
+	defer reset()
+
+	c, _ := New("HH.mm.SS", "")
+	if r := c.Release(); r != "4.5.9" {
+		t.Errorf("release version should be 4.5.9 but it was %s", r)
+	}
+
+	c, _ = New("0H.0m.0S", "")
+	if r := c.Release(); r != "04.05.09" {
+		t.Errorf("release version should be 04.05.09 but it was %s", r)
+	}
+}
+
+func TestNew_CompoundFormat(t *testing.T) {
+	for _, f := range []string{"YYYY.0M0D.0H0m", "YYYY0M.0D.0H0m0S", "YYYY0W.DD"} {
+		if _, err := New(f, ""); err != nil {
+			t.Errorf("format %s should be supported: %s", f, err)
+		}
+	}
+
+	for _, f := range []string{"YYYY.MM0D", "0Y0M.0D", "YYYY.0M0X", "YYYY.0M0D.0H.0m"} {
+		if _, err := New(f, ""); err == nil {
+			t.Errorf("format %s should not be supported", f)
+		}
+	}
+}
+
+func TestParse_Clock(t *testing.T) {
+	c, err := Parse("2007.0205.1405-3", "YYYY.0M0D.0H0m", "")
+	if err != nil {
+		t.Fatalf("failed to parse the version: %s", err)
+	}
+
+	if c.String() != "2007.0205.1405-3" {
+		t.Errorf("failed to parse the version, expected 2007.0205.1405-3 but got %s", c.String())
+	}
+
+	for _, raw := range []string{"2007.0205.2405", "2007.0205.1460", "2007.0205.145", "2007.1305.1405"} {
+		if _, err := Parse(raw, "YYYY.0M0D.0H0m", ""); err == nil {
+			t.Errorf("invalid version %s should not be parsed", raw)
+		}
+	}
+}
+
+func TestNew_YYYYDDD(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2007, 2, 5, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	c, _ := New("YYYY.DDD", "")
+	if r := c.Release(); r != "2007.36" {
+		t.Errorf("release version should be 2007.36 but it was %s", r)
+	}
+
+	if r := c.Release(); r != "2007.36-1" {
+		t.Errorf("release version should be 2007.36-1 but it was %s", r)
+	}
+
+	c, _ = New("YY.0DDD", "")
+	if r := c.Release(); r != "7.036" {
+		t.Errorf("release version should be 7.036 but it was %s", r)
+	}
+}
+
+func TestNew_YYQMICRO(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2007, 2, 5, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	c, _ := New("YY.Q.MICRO", "")
+	if c.String() != "YY.Q.MICRO" {
+		t.Error("empty version doesn't return the format")
+	}
+
+	const (
+		v0 = "7.1.0"
+		v1 = "7.1.1"
+		v2 = "7.1.2-dev"
+		v3 = "7.1.2"
+		v4 = "7.1.3-dev"
+		v5 = "7.1.4-dev"
+	)
+
+	r0 := c.Release()
+	if r0 != v0 {
+		t.Errorf("release version should be %s but it was %s", v0, r0)
+	}
+
+	r1 := c.Release()
+	if r1 != v1 {
+		t.Errorf("release version should be %s but it was %s", v1, r1)
+	}
+
+	r2 := c.PreRelease()
+	if r2 != v2 {
+		t.Errorf("prerelease version should be %s but it was %s", v2, r2)
+	}
+
+	r3 := c.Release()
+	if r3 != v3 {
+		t.Errorf("release version should be %s but it was %s", v3, r3)
+	}
+
+	r4 := c.PreRelease()
+	if r4 != v4 {
+		t.Errorf("prerelease version should be %s but it was %s", v4, r4)
+	}
+
+	r5 := c.PreRelease()
+	if r5 != v5 {
+		t.Errorf("prerelease version should be %s but it was %s", v5, r5)
+	}
+
+	now = func() time.Time {
+		return time.Date(2007, 4, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	if r := c.Release(); r != "7.2.0" {
+		t.Errorf("release version should be 7.2.0 but it was %s", r)
+	}
+}
+
+func TestNew_MicroPosition(t *testing.T) {
+	for _, f := range []string{"MICRO.YY", "YY.MICRO.MM", "YY.MMMICRO"} {
+		if _, err := New(f, ""); err == nil {
+			t.Errorf("format %s should not be supported", f)
+		}
+	}
+}
+
+func TestParse_DayOfYearQuarter(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2007, 2, 5, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	cases := map[string]string{
+		"2020.366":       "YYYY.DDD",
+		"2007.036":       "YYYY.0DDD",
+		"7.4.12":         "YY.Q.MICRO",
+		"7.4.12-dev":     "YY.Q.MICRO",
+		"2007.1.5-dev.2": "YYYY.Q.DD",
+	}
+
+	for raw, format := range cases {
+		c, err := Parse(raw, format, "")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", raw, err)
+		}
+
+		if c.String() != raw {
+			t.Errorf("failed to parse the version, expected %s but got %s", raw, c.String())
+		}
+	}
+
+	p, _ := Parse("7.4.12-dev", "YY.Q.MICRO", "", WithMonotonic(MonotonicAllow))
+	if r := p.Release(); r != "7.1.0" {
+		t.Errorf("release version should be 7.1.0 but it was %s", r)
+	}
+
+	p, _ = Parse("7.1.12-dev", "YY.Q.MICRO", "")
+	if r := p.Release(); r != "7.1.12" {
+		t.Errorf("release version should be 7.1.12 but it was %s", r)
+	}
+
+	invalid := map[string]string{
+		"2007.366":    "YYYY.DDD",
+		"2007.367":    "YYYY.DDD",
+		"2007.36":     "YYYY.0DDD",
+		"7.5.1":       "YY.Q.MICRO",
+		"7.1.01":      "YY.Q.MICRO",
+		"7.1.1-2":     "YY.Q.MICRO",
+		"7.1.1-dev.2": "YY.Q.MICRO",
+		"370.1":       "DDD.MM",
+		"367.1":       "0DDD.MM",
+	}
+
+	for raw, format := range invalid {
+		if _, err := Parse(raw, format, ""); err == nil {
+			t.Errorf("invalid version %s should not be parsed with %s", raw, format)
+		}
+	}
+
+	// 53 whole weeks of a retail year could take up to 371 days
+	if _, err := Parse("370.1", "DDD.MM", "", WithCalendar(Calendar{Retail: true})); err != nil {
+		t.Errorf("version 370.1 should be parsed with a retail calendar: %s", err)
+	}
+}
+
+func TestNew_ShortYearBeyondCentury(t *testing.T) {
+	cases := []struct {
+		year   int
+		short  string
+		padded string
+	}{
+		{2000, "0", "00"},
+		{2007, "7", "07"},
+		{2099, "99", "99"},
+		{2100, "100", "100"},
+		{2106, "106", "106"},
+	}
+
+	for _, tc := range cases {
+		reset := mockNowFunc(func() time.Time {
+			return time.Date(tc.year, 2, 5, 0, 0, 0, 0, time.UTC)
+		})
+
+		c, _ := New("YY.MM", "")
+		if r := c.Release(); r != tc.short+".2" {
+			t.Errorf("release version in %d should be %s.2 but it was %s", tc.year, tc.short, r)
+		}
+
+		c, _ = New("0Y.MM", "")
+		if r := c.Release(); r != tc.padded+".2" {
+			t.Errorf("release version in %d should be %s.2 but it was %s", tc.year, tc.padded, r)
+		}
+
+		reset()
+	}
+
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	for _, format := range []string{"YY.MM", "0Y.MM"} {
+		c, _ := New(format, "")
+		if r := c.Release(); c.Err() == nil || r != format {
+			t.Errorf("release version in 1999 should not be generated with %s but it was %s", format, r)
+		}
+	}
+
+	c, _ := Parse("0.1", "YY.MM", "")
+	if p, err := c.Previous(); err == nil {
+		t.Errorf("version before 0.1 should not be generated but it was %s", p)
+	}
+}
+
+func TestParse_ShortYear(t *testing.T) {
+	valid := map[string]string{
+		"106.2":       "YY.MM",
+		"0.2":         "YY.MM",
+		"106.2-dev.1": "0Y.MM",
+		"00.2":        "0Y.MM",
+		"69.2":        "0Y.MM",
+	}
+
+	for raw, format := range valid {
+		c, err := Parse(raw, format, "")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", raw, err)
+		}
+
+		if c.String() != raw {
+			t.Errorf("failed to parse the version, expected %s but got %s", raw, c.String())
+		}
+	}
+
+	c, _ := Parse("106.2", "YY.MM", "")
+	n, err := c.Convert("YYYY.MM")
+	if err != nil {
+		t.Fatalf("failed to convert %s: %s", c, err)
+	}
+
+	if n.String() != "2106.2" {
+		t.Errorf("converted version of %s should be 2106.2 but it was %s", c, n)
+	}
+
+	invalid := map[string]string{
+		"-1.2":   "YY.MM",
+		"07.2":   "YY.MM",
+		"7.2":    "0Y.MM",
+		"-01.2":  "0Y.MM",
+		"8000.2": "YY.MM",
+	}
+
+	for raw, format := range invalid {
+		if _, err := Parse(raw, format, ""); err == nil {
+			t.Errorf("invalid version %s should not be parsed with %s", raw, format)
+		}
+	}
+}
+
+func TestCalVer_Accessors(t *testing.T) {
+	c, _ := Parse("2020.12.20-dev.2", "YYYY.0M.0D", "dev")
+	if c.Major() != "2020" || c.Minor() != "12" || c.Micro() != "20" || c.Iteration() != 2 ||
+		!c.IsPreRelease() || c.Modifier() != "dev" || c.Format() != "YYYY.0M.0D" {
+		t.Errorf("segments of %s should be 2020, 12, 20 and dev.2 but they were %s, %s, %s and %s.%d", c, c.Major(), c.Minor(), c.Micro(), c.Modifier(), c.Iteration())
+	}
+
+	c, _ = Parse("21.3.4", "YY.Q.MICRO", "dev")
+	if c.Micro() != "4" || c.Iteration() != 0 {
+		t.Errorf("micro of %s should be 4 but it was %s", c, c.Micro())
+	}
+}
@@ -0,0 +1,511 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/umayr/calver"
+)
+
+// next prints the next version, following the provided one if there's any
+func next(args []string) {
+	fs := newFlagSet("next", "[version]")
+	fs.BoolVar(flagPre, "pre-release", *flagPre, "flag to create a prerelease")
+	fs.BoolVar(flagGoModule, "go-module", *flagGoModule, "flag to read and print versions as go module tags")
+	fs.UintVar(flagGoMajor, "go-major", *flagGoMajor, "major version for go module tags, either 0 or 1")
+	fs.StringVar(flagOutput, "output", *flagOutput, "how to print the version: text, json, yaml or env")
+	fs.StringVar(flagTemplate, "template", *flagTemplate, "go template to print the version with, like {{.Major}}.{{.Minor}}")
+	fs.Parse(args)
+
+	previous := ""
+	if fs.NArg() > 0 {
+		previous = fs.Arg(fs.NArg() - 1)
+	}
+
+	if err := nextVersion(os.Stdout, previous); err != nil {
+		fail(err)
+	}
+}
+
+// nextVersion prints the next version to w for next, following the previous
+// one unless it's empty
+func nextVersion(w io.Writer, previous string) error {
+	var (
+		c   *calver.CalVer
+		err error
+	)
+
+	if previous == "" {
+		c, err = calver.New(*flagFormat, *flagModifier, options()...)
+	} else if *flagGoModule {
+		c, err = calver.ParseGoModule(previous, *flagFormat, *flagModifier, options()...)
+	} else if c, err = calver.Parse(previous, *flagFormat, *flagModifier, options()...); err == nil {
+		previous = c.String()
+	}
+	if err != nil {
+		return err
+	}
+
+	v, err := c.Next(*flagPre)
+	if err != nil {
+		return err
+	}
+
+	if *flagGoModule {
+		if v, err = c.GoModule(*flagGoMajor); err != nil {
+			return err
+		}
+	}
+
+	return printVersion(w, c, v, previous, false)
+}
+
+// parse prints the segments of the version, one per line unless another
+// output is provided
+func parse(args []string) {
+	fs := newFlagSet("parse", "<version>")
+	fs.StringVar(flagOutput, "output", *flagOutput, "how to print the segments: text, json, yaml or env")
+	fs.StringVar(flagTemplate, "template", *flagTemplate, "go template to print the version with, like {{.Major}}.{{.Minor}}")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	c := parseVersion(fs.Arg(0))
+	if err := printVersion(os.Stdout, c, c.String(), "", true); err != nil {
+		fail(err)
+	}
+}
+
+// lines returns the arguments, or the lines from stdin when there aren't any,
+// leaving out the empty ones
+func lines(args []string) []string {
+	if len(args) > 0 {
+		return args
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// validate checks that the versions match the format, printing why the ones
+// that don't, and exits with 1 if there's any of them. The versions are read
+// from stdin when there aren't any arguments
+func validate(args []string) {
+	fs := newFlagSet("validate", "[version]...")
+	strict := fs.Bool("strict", false, "flag to reject dates that don't exist or are yet to come, formats without a year are only checked segment by segment")
+	fs.Parse(args)
+
+	os.Exit(validateLines(os.Stdout, lines(fs.Args()), *strict))
+}
+
+// validateLines checks the versions for validate, printing why the invalid
+// ones are to w, and returns the code to exit with
+func validateLines(w io.Writer, raws []string, strict bool) int {
+	opts := options()
+	if strict {
+		opts = append(opts, calver.WithStrict())
+	}
+
+	code := 0
+	for _, raw := range raws {
+		if _, err := calver.Parse(raw, *flagFormat, *flagModifier, opts...); err != nil {
+			fmt.Fprintf(w, "%s: %s\n", raw, err)
+			code = 1
+		}
+	}
+
+	return code
+}
+
+// exit codes of compare with --exit-code, anything that goes wrong exits with
+// 2 so it can't be taken for any of them
+const (
+	exitEqual   = 0
+	exitLess    = 10
+	exitGreater = 11
+)
+
+// relations are the ones compare could assert with --op, by what Compare
+// returns for them
+var relations = map[string]func(r int) bool{
+	"lt": func(r int) bool { return r < 0 },
+	"le": func(r int) bool { return r <= 0 },
+	"eq": func(r int) bool { return r == 0 },
+	"ge": func(r int) bool { return r >= 0 },
+	"gt": func(r int) bool { return r > 0 },
+}
+
+// compare prints -1, 0 or 1 if the first version comes before, is the same as
+// or comes after the second one. With --op it prints nothing and exits with 0
+// if the relation holds and 1 otherwise, and with --exit-code it exits with a
+// code for each outcome as well
+func compare(args []string) {
+	fs := newFlagSet("compare", "<version> <version>")
+	op := fs.String("op", "", "relation to assert between the versions: lt, le, eq, ge or gt")
+	code := fs.Bool("exit-code", false, fmt.Sprintf("flag to exit with %d if the first version is less, %d if equal and %d if greater", exitLess, exitEqual, exitGreater))
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	os.Exit(compareVersions(os.Stdout, fs.Arg(0), fs.Arg(1), *op, *code))
+}
+
+// compareVersions compares the versions for compare, printing the outcome or
+// what went wrong to w, and returns the code to exit with
+func compareVersions(w io.Writer, a, b, op string, code bool) int {
+	holds, ok := relations[op]
+	if op != "" && !ok {
+		fmt.Fprintf(w, "invalid relation: %s\n", op)
+		return 2
+	}
+
+	var versions [2]*calver.CalVer
+	for i, raw := range []string{a, b} {
+		c, err := calver.Parse(raw, *flagFormat, *flagModifier, options()...)
+		if err != nil {
+			fmt.Fprintln(w, err.Error())
+			return 2
+		}
+
+		versions[i] = c
+	}
+
+	r := calver.Compare(versions[0], versions[1])
+
+	if op != "" {
+		if !holds(r) {
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintln(w, r)
+
+	switch {
+	case !code:
+		return 0
+	case r < 0:
+		return exitLess
+	case r > 0:
+		return exitGreater
+	default:
+		return exitEqual
+	}
+}
+
+// symbols are the relations a constraint could have, longest first so that
+// `>=` isn't taken for `>`
+var symbols = []struct {
+	symbol   string
+	relation string
+}{
+	{">=", "ge"},
+	{"<=", "le"},
+	{"==", "eq"},
+	{">", "gt"},
+	{"<", "lt"},
+	{"=", "eq"},
+}
+
+// constraint is a relation that versions need to hold with another one
+type constraint struct {
+	holds   func(r int) bool
+	version *calver.CalVer
+}
+
+// parseConstraints parses comma separated constraints like `>=2021.1.1,<2021.6.1`
+func parseConstraints(raw string) ([]constraint, error) {
+	var constraints []constraint
+	for _, c := range strings.Split(raw, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+
+		found := false
+		for _, s := range symbols {
+			if strings.HasPrefix(c, s.symbol) {
+				v, err := calver.Parse(strings.TrimSpace(strings.TrimPrefix(c, s.symbol)), *flagFormat, *flagModifier, options()...)
+				if err != nil {
+					return nil, err
+				}
+
+				constraints = append(constraints, constraint{relations[s.relation], v})
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("invalid constraint: %s", c)
+		}
+	}
+
+	return constraints, nil
+}
+
+// entry is a version along with how it was written
+type entry struct {
+	raw     string
+	version *calver.CalVer
+}
+
+// parseVersions parses all the versions, sorted from the oldest to the latest.
+// The ones that don't match the format are left out, reported to w or make it
+// fail, depending on the provided mode
+func parseVersions(w io.Writer, raws []string, invalid string) ([]entry, error) {
+	if invalid != "ignore" && invalid != "report" && invalid != "fail" {
+		return nil, fmt.Errorf("invalid mode for lines that aren't versions: %s", invalid)
+	}
+
+	var entries []entry
+	for _, raw := range raws {
+		c, err := calver.Parse(raw, *flagFormat, *flagModifier, options()...)
+		if err != nil {
+			switch invalid {
+			case "ignore":
+			case "report":
+				fmt.Fprintf(w, "%s: %s\n", raw, err)
+			default:
+				return nil, fmt.Errorf("%s: %s", raw, err)
+			}
+			continue
+		}
+
+		entries = append(entries, entry{raw, c})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return calver.Compare(entries[i].version, entries[j].version) < 0
+	})
+
+	return entries, nil
+}
+
+// sortVersions prints the versions from the oldest to the latest. The versions
+// are read from stdin when there aren't any arguments, so that it could be
+// used as a filter like `sort -V`
+func sortVersions(args []string) {
+	fs := newFlagSet("sort", "[version]...")
+	reverse := fs.Bool("reverse", false, "flag to sort from the latest to the oldest")
+	unique := fs.Bool("unique", false, "flag to print the same versions only once")
+	invalid := fs.String("invalid", "ignore", "what to do with lines that aren't versions: ignore, report or fail")
+	filter := fs.String("constraint", "", "comma separated constraints the versions need to hold, like >=2021.1.1,<2021.6.1")
+	fs.Parse(args)
+
+	os.Exit(sortLines(os.Stdout, os.Stderr, lines(fs.Args()), *filter, *invalid, *reverse, *unique))
+}
+
+// sortLines sorts the versions for sort, printing them or what went wrong to
+// w and the lines that aren't versions to report, and returns the code to
+// exit with
+func sortLines(w, report io.Writer, raws []string, filter, invalid string, reverse, unique bool) int {
+	constraints, err := parseConstraints(filter)
+	if err != nil {
+		fmt.Fprintln(w, err.Error())
+		return 1
+	}
+
+	parsed, err := parseVersions(report, raws, invalid)
+	if err != nil {
+		fmt.Fprintln(w, err.Error())
+		return 1
+	}
+
+	var entries []entry
+	for _, e := range parsed {
+		holds := true
+		for _, c := range constraints {
+			if !c.holds(calver.Compare(e.version, c.version)) {
+				holds = false
+				break
+			}
+		}
+
+		if !holds {
+			continue
+		}
+
+		if n := len(entries); unique && n > 0 && calver.Compare(entries[n-1].version, e.version) == 0 {
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	if reverse {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+
+	for _, e := range entries {
+		fmt.Fprintln(w, e.raw)
+	}
+
+	return 0
+}
+
+// latest prints the latest of the versions, which are read from stdin when
+// there aren't any arguments
+func latest(args []string) {
+	fs := newFlagSet("latest", "[version]...")
+	invalid := fs.String("invalid", "ignore", "what to do with lines that aren't versions: ignore, report or fail")
+	fs.Parse(args)
+
+	entries, err := parseVersions(os.Stderr, lines(fs.Args()), *invalid)
+	if err != nil {
+		fail(err)
+	}
+
+	if len(entries) == 0 {
+		fail(fmt.Errorf("there aren't any versions"))
+	}
+
+	fmt.Println(entries[len(entries)-1].raw)
+}
+
+// format prints the version converted into another format, or in one of the
+// forms used by packages
+func format(args []string) {
+	fs := newFlagSet("format", "<version>")
+	as := fs.String("as", "calver", "form to print the version in: calver, pep440, debian, rpm, go-module or sort-key")
+	to := fs.String("to", "", "format to convert the version into")
+	major := fs.Uint("go-major", 0, "major version for go module tags, either 0 or 1")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	if err := formatVersion(os.Stdout, fs.Arg(0), *as, *to, *major); err != nil {
+		fail(err)
+	}
+}
+
+// formatVersion prints the version to w for format, converted into the other
+// format first unless it's empty
+func formatVersion(w io.Writer, raw, as, to string, major uint) error {
+	c, err := calver.Parse(raw, *flagFormat, *flagModifier, options()...)
+	if err != nil {
+		return err
+	}
+
+	if to != "" {
+		if c, err = c.Convert(to); err != nil {
+			return err
+		}
+	}
+
+	switch as {
+	case "calver":
+		fmt.Fprintln(w, c)
+	case "pep440":
+		fmt.Fprintln(w, c.PEP440())
+	case "debian":
+		fmt.Fprintln(w, c.Debian())
+	case "rpm":
+		version, release := c.RPM()
+		fmt.Fprintln(w, version, release)
+	case "go-module":
+		tag, err := c.GoModule(major)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, tag)
+	case "sort-key":
+		fmt.Fprintln(w, c.SortKey())
+	default:
+		return fmt.Errorf("invalid form for the version: %s", as)
+	}
+
+	return nil
+}
+
+// infer prints all the formats that match the provided versions, best one
+// first. The versions are read from stdin when there aren't any arguments
+func infer(args []string) {
+	fs := newFlagSet("infer", "[version]...")
+	fs.Parse(args)
+
+	formats, err := calver.Infer(lines(fs.Args()))
+	if err != nil {
+		fail(err)
+	}
+
+	for _, f := range formats {
+		fmt.Println(f)
+	}
+}
+
+// schedule prints the releases the format would have for a cadence, along
+// with their days, following the provided version if there's one
+func schedule(args []string) {
+	fs := newFlagSet("schedule", "[version]")
+	cadence := fs.String("cadence", "monday", "days of the releases: daily, quarterly, a weekday or an ordinal weekday like first-tuesday")
+	from := fs.String("from", time.Now().Format("2006-01-02"), "first day of the schedule")
+	to := fs.String("to", "", "day the schedule ends before (default three months after the first day)")
+	ics := fs.Bool("ics", false, "flag to print the schedule as an iCalendar")
+	freeze := fs.Int("freeze", 0, "days before each release for a code freeze event in the iCalendar")
+	product := fs.String("product", "", "domain of the project to tell its events apart in the iCalendar, required with --ics")
+	fs.Parse(args)
+
+	cad, err := calver.ParseCadence(*cadence)
+	if err != nil {
+		fail(err)
+	}
+
+	start, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		fail(err)
+	}
+
+	end := start.AddDate(0, 3, 0)
+	if *to != "" {
+		if end, err = time.Parse("2006-01-02", *to); err != nil {
+			fail(err)
+		}
+	}
+
+	var c *calver.CalVer
+	if fs.NArg() > 0 {
+		c = parseVersion(fs.Arg(0))
+	} else if c, err = calver.New(*flagFormat, *flagModifier, options()...); err != nil {
+		fail(err)
+	}
+
+	planned, err := c.Schedule(cad, start, end)
+	if err != nil {
+		fail(err)
+	}
+
+	if *ics {
+		if err := calver.ICalendar(os.Stdout, *product, planned, *freeze); err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	for _, p := range planned {
+		fmt.Printf("%s\t%s\n", p.Date.Format("2006-01-02"), p.Version)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b, op string
+		code     bool
+		exit     int
+		printed  string
+	}{
+		{"2021.3.5", "2021.3.6", "", false, 0, "-1"},
+		{"2021.3.6", "2021.3.5", "", false, 0, "1"},
+		{"2021.3.5", "2021.3.6", "", true, exitLess, "-1"},
+		{"2021.3.5-1", "2021.3.5-1", "", true, exitEqual, "0"},
+		{"2021.10.1", "2021.9.30", "", true, exitGreater, "1"},
+		{"2021.3.5", "2021.3.6", "lt", false, 0, ""},
+		{"2021.3.5", "2021.3.6", "ge", false, 1, ""},
+		{"2021.3.5", "2021.3.5", "le", true, 0, ""},
+		{"2021.3.5", "2021.3.6", "ne", false, 2, "invalid relation: ne"},
+		{"2021.3", "2021.3.6", "", true, 2, "provided string doesn't match the format: YYYY.MM.DD"},
+	}
+
+	for _, tc := range cases {
+		var b bytes.Buffer
+		exit := compareVersions(&b, tc.a, tc.b, tc.op, tc.code)
+		if exit != tc.exit {
+			t.Errorf("exit code comparing %s and %s (op: %q, exit code: %t) should be %d but it was %d", tc.a, tc.b, tc.op, tc.code, tc.exit, exit)
+		}
+
+		if printed := strings.TrimSpace(b.String()); printed != tc.printed {
+			t.Errorf("output comparing %s and %s should be %q but it was %q", tc.a, tc.b, tc.printed, printed)
+		}
+	}
+}
+
+func TestCompareVersions_InvalidOptions(t *testing.T) {
+	defer func(m string) { *flagMonotonic = m }(*flagMonotonic)
+	*flagMonotonic = "never"
+
+	var b bytes.Buffer
+	if exit := compareVersions(&b, "2021.3.5", "2021.3.6", "", true); exit != 2 {
+		t.Errorf("exit code with an invalid policy should be 2 but it was %d", exit)
+	}
+}
+
+func TestSortLines(t *testing.T) {
+	raws := []string{"2021.3.5-1", "2021.10.1", "tip", "2021.3.5-1", "2021.9.30", "2020.12.20"}
+
+	cases := []struct {
+		filter, invalid string
+		reverse, unique bool
+		exit            int
+		printed         []string
+		reported        []string
+	}{
+		{"", "ignore", false, false, 0, []string{"2020.12.20", "2021.3.5-1", "2021.3.5-1", "2021.9.30", "2021.10.1"}, nil},
+		{"", "report", true, true, 0, []string{"2021.10.1", "2021.9.30", "2021.3.5-1", "2020.12.20"}, []string{"tip: provided string doesn't match the format: YYYY.MM.DD"}},
+		{">=2021.1.1, <2021.10.1", "ignore", false, true, 0, []string{"2021.3.5-1", "2021.9.30"}, nil},
+		{"==2021.3.5-1", "ignore", false, false, 0, []string{"2021.3.5-1", "2021.3.5-1"}, nil},
+		{"", "fail", false, false, 1, []string{"tip: provided string doesn't match the format: YYYY.MM.DD"}, nil},
+		{"", "skip", false, false, 1, []string{"invalid mode for lines that aren't versions: skip"}, nil},
+		{"~2021.1.1", "ignore", false, false, 1, []string{"invalid constraint: ~2021.1.1"}, nil},
+		{">2021", "ignore", false, false, 1, []string{"provided string doesn't match the format: YYYY.MM.DD"}, nil},
+	}
+
+	for _, tc := range cases {
+		var w, report bytes.Buffer
+		exit := sortLines(&w, &report, raws, tc.filter, tc.invalid, tc.reverse, tc.unique)
+		if exit != tc.exit {
+			t.Errorf("exit code sorting with %q (invalid: %s) should be %d but it was %d", tc.filter, tc.invalid, tc.exit, exit)
+		}
+
+		if printed := strings.TrimSpace(w.String()); printed != strings.Join(tc.printed, "\n") {
+			t.Errorf("sorted versions with %q (reverse: %t, unique: %t) should be %v but they were %q", tc.filter, tc.reverse, tc.unique, tc.printed, printed)
+		}
+
+		if reported := strings.TrimSpace(report.String()); reported != strings.Join(tc.reported, "\n") {
+			t.Errorf("reported lines should be %v but they were %q", tc.reported, reported)
+		}
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	for _, tc := range []struct {
+		args    []string
+		command string
+		rest    []string
+	}{
+		{[]string{"2020.12.20"}, "next", []string{"2020.12.20"}},
+		{[]string{"next", "2020.12.20"}, "next", []string{"2020.12.20"}},
+		{[]string{"--pre-release", "2020.12.20"}, "next", []string{"--pre-release", "2020.12.20"}},
+		{nil, "next", nil},
+		{[]string{"format", "--as", "rpm", "2020.12.20"}, "format", []string{"--as", "rpm", "2020.12.20"}},
+	} {
+		c, rest := dispatch(tc.args)
+		if c.name != tc.command || strings.Join(rest, " ") != strings.Join(tc.rest, " ") {
+			t.Errorf("command for %v should be %s %v but it was %s %v", tc.args, tc.command, tc.rest, c.name, rest)
+		}
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	defer func(m string, pre, gomod bool) {
+		*flagMonotonic, *flagPre, *flagGoModule = m, pre, gomod
+	}(*flagMonotonic, *flagPre, *flagGoModule)
+
+	// a version from the future is kept so that the outcome doesn't depend
+	// on the clock
+	*flagMonotonic = "keep"
+
+	for _, tc := range []struct {
+		previous string
+		pre      bool
+		gomod    bool
+		expected string
+	}{
+		{"2999.1.1-1", false, false, "2999.1.1-2"},
+		{"2999.1.1-1", true, false, "2999.1.1-dev.2"},
+		{"v0.29990101.1", false, true, "v0.29990101.2"},
+	} {
+		*flagPre, *flagGoModule = tc.pre, tc.gomod
+
+		var b bytes.Buffer
+		if err := nextVersion(&b, tc.previous); err != nil {
+			t.Errorf("failed to release after %s: %s", tc.previous, err)
+			continue
+		}
+
+		if actual := strings.TrimSpace(b.String()); actual != tc.expected {
+			t.Errorf("next version after %s should be %s but it was %s", tc.previous, tc.expected, actual)
+		}
+	}
+
+	*flagMonotonic, *flagPre, *flagGoModule = "refuse", false, false
+	if err := nextVersion(&bytes.Buffer{}, "2999.1.1"); err == nil {
+		t.Error("next version going backwards should be reported")
+	}
+
+	if err := nextVersion(&bytes.Buffer{}, "2999.1"); err == nil {
+		t.Error("invalid version 2999.1 should not be released after")
+	}
+}
+
+func TestFormatVersion(t *testing.T) {
+	for _, tc := range []struct {
+		raw, as, to string
+		expected    string
+	}{
+		{"2020.12.20-dev.2", "calver", "", "2020.12.20-dev.2"},
+		{"2020.12.20-dev.2", "pep440", "", "2020.12.20.post2.dev0"},
+		{"2020.12.20-dev.2", "debian", "", "2020.12.20-2~dev"},
+		{"2020.12.20-dev.2", "rpm", "", "2020.12.20 2~dev"},
+		{"2020.12.20-dev.2", "go-module", "", "v0.20201220.2-dev"},
+		{"2020.12.20-dev.2", "sort-key", "", "2020.12.20.00000000000000000002.0"},
+		{"2020.12.20", "calver", "YYYY.0M", "2020.12"},
+	} {
+		var b bytes.Buffer
+		if err := formatVersion(&b, tc.raw, tc.as, tc.to, 0); err != nil {
+			t.Errorf("failed to format %s as %s: %s", tc.raw, tc.as, err)
+			continue
+		}
+
+		if actual := strings.TrimSpace(b.String()); actual != tc.expected {
+			t.Errorf("%s form of %s should be %s but it was %s", tc.as, tc.raw, tc.expected, actual)
+		}
+	}
+
+	for _, tc := range [][3]string{
+		{"2020.12.20", "semver", ""},
+		{"2020.12.20-1", "calver", "YYYY.0M"},
+		{"2020.12", "calver", ""},
+	} {
+		if err := formatVersion(&bytes.Buffer{}, tc[0], tc[1], tc[2], 0); err == nil {
+			t.Errorf("%s should not be formatted as %s into %q", tc[0], tc[1], tc[2])
+		}
+	}
+}
+
+func TestValidateLines(t *testing.T) {
+	raws := []string{"2021.3.5", "2021.2.30", "2021.3.5-", "3000.1.1", "tip"}
+
+	var b bytes.Buffer
+	if exit := validateLines(&b, raws, false); exit != 1 {
+		t.Errorf("exit code with invalid versions should be 1 but it was %d", exit)
+	}
+
+	expected := strings.Join([]string{
+		"2021.3.5-: provided string doesn't match the format: YYYY.MM.DD",
+		"tip: provided string doesn't match the format: YYYY.MM.DD",
+	}, "\n")
+	if reported := strings.TrimSpace(b.String()); reported != expected {
+		t.Errorf("reported lines should be:\n%s\nbut they were:\n%s", expected, reported)
+	}
+
+	b.Reset()
+	if exit := validateLines(&b, raws, true); exit != 1 {
+		t.Errorf("exit code with invalid versions should be 1 but it was %d", exit)
+	}
+
+	expected = strings.Join([]string{
+		"2021.2.30: provided string isn't a valid date for the format: YYYY.MM.DD",
+		"2021.3.5-: provided string doesn't match the format: YYYY.MM.DD",
+		"3000.1.1: provided string is a date in the future: 3000-01-01",
+		"tip: provided string doesn't match the format: YYYY.MM.DD",
+	}, "\n")
+	if reported := strings.TrimSpace(b.String()); reported != expected {
+		t.Errorf("reported lines should be:\n%s\nbut they were:\n%s", expected, reported)
+	}
+
+	b.Reset()
+	if exit := validateLines(&b, raws[:1], true); exit != 0 || b.Len() != 0 {
+		t.Errorf("exit code with valid versions should be 0 but it was %d (%s)", exit, b.String())
+	}
+}
@@ -4,27 +4,88 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/umayr/calver"
 )
 
 var (
-	flagFormat   = flag.String("format", "YYYY.MM.DD", "format to parse the provided version")
-	flagPre      = flag.Bool("pre-release", false, "flag to create a prerelease")
-	flagModifier = flag.String("modifier", "dev", "modifier for prerelease versions")
+	flagFormat    = flag.String("format", "YYYY.MM.DD", "format to parse the provided version")
+	flagPre       = flag.Bool("pre-release", false, "flag to create a prerelease")
+	flagModifier  = flag.String("modifier", "dev", "modifier for prerelease versions")
+	flagGoModule  = flag.Bool("go-module", false, "flag to read and print versions as go module tags")
+	flagGoMajor   = flag.Uint("go-major", 0, "major version for go module tags, either 0 or 1")
+	flagFiscal    = flag.Int("fiscal-start", 1, "month the fiscal year starts in, from 1 to 12")
+	flagRetail    = flag.Bool("retail", false, "flag to use a 4-4-5 retail calendar")
+	flagMonotonic = flag.String("monotonic", "refuse", "what to do when the clock is behind the version: refuse, keep or allow")
+	flagOutput    = flag.String("output", "text", "how to print the version: text, json, yaml or env")
+	flagTemplate  = flag.String("template", "", "go template to print the version with, like {{.Major}}.{{.Minor}}")
 )
 
+// command is a subcommand of the CLI
+type command struct {
+	name    string
+	summary string
+	run     func(args []string)
+}
+
+var commands []command
+
 func init() {
+	commands = []command{
+		{"next", "print the next version, which is what calver does without a command", next},
+		{"parse", "print the segments of a version", parse},
+		{"validate", "check that versions match the format", validate},
+		{"compare", "compare two versions", compare},
+		{"sort", "sort versions from the oldest to the latest", sortVersions},
+		{"latest", "print the latest of the versions", latest},
+		{"format", "print a version in another form or format", format},
+		{"infer", "print the formats that match the versions, best one first", infer},
+		{"schedule", "print the releases the format would have for a cadence", schedule},
+	}
+
 	flag.Usage = func() {
 		fmt.Fprint(os.Stderr, `calver is a small utility to handle calender versioning:
 
 Usage:
+  calver [flags] [command] [arguments]
+
+Commands:
+`)
+		for _, c := range commands {
+			fmt.Fprintf(os.Stderr, "  %-10s%s\n", c.name, c.summary)
+		}
+
+		fmt.Fprint(os.Stderr, `
+Use "calver [command] --help" for more information about a command.
+
+Flags:
+  --fiscal-start int
+		month the fiscal year starts in, from 1 to 12 (default 1)
   --format string
 		format to parse the provided version (default "YYYY.MM.DD")
+  --go-major uint
+		major version for go module tags, either 0 or 1
+  --go-module
+		flag to read and print versions as go module tags
   --modifier string
 		modifier for prerelease versions (default "dev")
+  --monotonic string
+		what to do when the clock is behind the version: refuse, keep or allow (default "refuse")
+  --output string
+		how to print the version: text, json, yaml or env (default "text")
   --pre-release
 		flag to create a prerelease
+  --retail
+		flag to use a 4-4-5 retail calendar
+  --template string
+		go template to print the version with, like {{.Major}}.{{.Minor}}
+
+Template fields are .Version, .Format, .Major, .Minor, .Micro, .Iteration,
+.PreRelease, .Modifier, .Date, .Previous and .Time, along with the semver,
+gomodule, pep440, debian, rpm, sortkey, pad, upper, lower and replace functions.
+.Previous is the version the next one follows, empty when there isn't any.
 
 Example:
   $ calver 2020.12.20
@@ -42,39 +103,153 @@ Example:
   $ calver --format YY.MM 19.01
   20.12
 
+  $ calver --go-module v0.20201220.1
+  v0.20201220.2
+
+  $ calver --format YYYY.Q --fiscal-start 4 2020.4
+  2021.3
+
+  $ calver --monotonic keep 2030.1.1
+  2030.1.1-1
+
+  $ calver --output env 2020.12.20
+  CALVER_VERSION=2020.12.20-1
+  CALVER_FORMAT=YYYY.MM.DD
+  CALVER_MAJOR=2020
+  CALVER_MINOR=12
+  CALVER_MICRO=20
+  CALVER_ITERATION=1
+  CALVER_PRERELEASE=false
+  CALVER_MODIFIER=dev
+  CALVER_DATE=2020-12-20
+  CALVER_PREVIOUS=2020.12.20
+
+  $ calver compare 2020.12.20-dev.1 2020.12.20
+  1
+
+  $ calver compare --op gt 2020.12.20-dev.1 2020.12.20 && echo newer
+  newer
+
+  $ calver sort 2020.12.20-1 2020.12.9 2020.12.20-dev.1
+  2020.12.9
+  2020.12.20-dev.1
+  2020.12.20-1
+
+  $ git tag | calver validate --strict
+  2021.2.30: provided string isn't a valid date for the format: YYYY.MM.DD
+
+  $ git tag | calver sort --reverse --unique --constraint ">=2020.1.1"
+  2020.12.20-1
+  2020.12.20-dev.1
+  2020.12.9
+
+  $ calver format --as pep440 2020.12.20-dev.2
+  2020.12.20.post2.dev0
+
+  $ calver --template 'app:{{.Major}}.{{pad 2 .Minor}}-{{semver}}' 2020.12.20
+  app:2020.12-0.20201220.1
+
+  $ git tag | calver infer
+  YYYY.0M.0D
+  YYYY.0W.0D
+
+  $ calver --format YYYY.0M.0D schedule --cadence first-tuesday --from 2021-04-01 --to 2021-07-01
+  2021-04-06	2021.04.06
+  2021-05-04	2021.05.04
+  2021-06-01	2021.06.01
+
+  $ calver schedule --cadence monday --ics --product example.com --freeze 2 > releases.ics
+
 For more information about Calender Versioning, please visit https://calver.org
 `)
 	}
+}
 
-	flag.Parse()
+// fail prints the error and exits
+func fail(err error) {
+	exit(1, err)
 }
 
-func main() {
-	args := flag.Args()
+// exit prints the error and exits with the provided code
+func exit(code int, err error) {
+	fmt.Println(err.Error())
+	os.Exit(code)
+}
+
+// newFlagSet returns the flags of a command, along with the ones every
+// command takes, which are bound to the same values as the ones that could
+// go before the command
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.StringVar(flagFormat, "format", *flagFormat, "format to parse the provided version")
+	fs.StringVar(flagModifier, "modifier", *flagModifier, "modifier for prerelease versions")
+	fs.IntVar(flagFiscal, "fiscal-start", *flagFiscal, "month the fiscal year starts in, from 1 to 12")
+	fs.BoolVar(flagRetail, "retail", *flagRetail, "flag to use a 4-4-5 retail calendar")
+	fs.StringVar(flagMonotonic, "monotonic", *flagMonotonic, "what to do when the clock is behind the version: refuse, keep or allow")
+
+	fs.Usage = func() {
+		for _, c := range commands {
+			if c.name == name {
+				fmt.Fprintf(os.Stderr, "%s\n\n", strings.ToUpper(c.summary[:1])+c.summary[1:])
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "Usage:\n  calver %s [flags] %s\n\nFlags:\n", name, usage)
+		fs.PrintDefaults()
+	}
 
-	var (
-		c   *calver.CalVer
-		err error
-	)
+	return fs
+}
 
-	if len(args) == 0 {
-		c, err = calver.New(*flagFormat, *flagModifier)
-	} else {
-		version := args[len(args)-1]
+// options returns the options for the versions out of the flags, an invalid
+// policy makes the version fail to be created so that it's reported by the
+// command like any other error
+func options() []calver.Option {
+	monotonic, err := calver.ParseMonotonic(*flagMonotonic)
+	if err != nil {
+		return []calver.Option{func(*calver.CalVer) error { return err }}
+	}
 
-		c, err = calver.Parse(version, *flagFormat, *flagModifier)
+	return []calver.Option{
+		calver.WithCalendar(calver.Calendar{Start: time.Month(*flagFiscal), Retail: *flagRetail}),
+		calver.WithMonotonic(monotonic),
 	}
+}
+
+// parseVersion parses the version for the format and modifier in the flags
+func parseVersion(raw string) *calver.CalVer {
+	c, err := calver.Parse(raw, *flagFormat, *flagModifier, options()...)
 	if err != nil {
-		fmt.Println(err.Error())
-		os.Exit(1)
+		fail(err)
+	}
+
+	return c
+}
+
+// dispatch returns the command for the arguments along with the ones left for
+// it, which is next when there isn't any command so that it keeps working the
+// way it did before there were any
+func dispatch(args []string) (command, []string) {
+	if len(args) > 0 {
+		for _, c := range commands {
+			if c.name == args[0] {
+				return c, args[1:]
+			}
+		}
 	}
 
-	var next string
-	if *flagPre {
-		next = c.PreRelease()
-	} else {
-		next = c.Release()
+	return commands[0], args
+}
+
+func main() {
+	flag.Parse()
+	args := flag.Args()
+
+	if len(args) > 0 && args[0] == "help" {
+		flag.Usage()
+		return
 	}
 
-	fmt.Println(next)
+	c, args := dispatch(args)
+	c.run(args)
 }
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/umayr/calver"
+)
+
+// output is what gets printed about a version in the other outputs than text
+type output struct {
+	Version    string `json:"version"`
+	Format     string `json:"format"`
+	Major      string `json:"major"`
+	Minor      string `json:"minor"`
+	Micro      string `json:"micro"`
+	Iteration  uint64 `json:"iteration"`
+	PreRelease bool   `json:"prerelease"`
+	Modifier   string `json:"modifier"`
+	Date       string `json:"date"`
+	Previous   string `json:"previous"`
+	// Time is when the period of the version starts, for templates
+	Time time.Time `json:"-"`
+}
+
+// newOutput returns the output for the version, printed as the provided
+// string, following the previous one if there's any. The date is left empty
+// when the format can't tell it
+func newOutput(c *calver.CalVer, printed, previous string) output {
+	o := output{
+		Version:    printed,
+		Format:     string(c.Format()),
+		Major:      c.Major(),
+		Minor:      c.Minor(),
+		Micro:      c.Micro(),
+		Iteration:  c.Iteration(),
+		PreRelease: c.IsPreRelease(),
+		Modifier:   c.Modifier(),
+		Previous:   previous,
+	}
+
+	if start, _, err := c.Period(); err == nil {
+		o.Time = start
+		if start.Equal(start.Truncate(24 * time.Hour)) {
+			o.Date = start.Format("2006-01-02")
+		} else {
+			o.Date = start.Format(time.RFC3339)
+		}
+	}
+
+	return o
+}
+
+// fields returns the name and the value of each field in order
+func (o output) fields() [][2]string {
+	return [][2]string{
+		{"version", o.Version},
+		{"format", o.Format},
+		{"major", o.Major},
+		{"minor", o.Minor},
+		{"micro", o.Micro},
+		{"iteration", strconv.FormatUint(o.Iteration, 10)},
+		{"prerelease", strconv.FormatBool(o.PreRelease)},
+		{"modifier", o.Modifier},
+		{"date", o.Date},
+		{"previous", o.Previous},
+	}
+}
+
+// print prints the output to w in the provided form, text being one field per
+// line unless the caller prints something else for it
+func (o output) print(w io.Writer, form string) error {
+	switch form {
+	case "text":
+		for _, f := range o.fields() {
+			fmt.Fprintf(w, "%s: %s\n", f[0], f[1])
+		}
+	case "json":
+		b, err := json.MarshalIndent(o, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(b))
+	case "yaml":
+		for _, f := range o.fields() {
+			v := f[1]
+			// the rest are strings, quoted so that `2021.3` isn't read as a number
+			if f[0] != "iteration" && f[0] != "prerelease" {
+				v = strconv.Quote(v)
+			}
+			fmt.Fprintf(w, "%s: %s\n", f[0], v)
+		}
+	case "env":
+		for _, f := range o.fields() {
+			fmt.Fprintf(w, "CALVER_%s=%s\n", strings.ToUpper(f[0]), f[1])
+		}
+	default:
+		return fmt.Errorf("invalid output: %s", form)
+	}
+
+	return nil
+}
+
+// funcs returns the helper functions for templates about the version
+func funcs(c *calver.CalVer) template.FuncMap {
+	return template.FuncMap{
+		// semver packs the segments into the minor like go module tags do,
+		// which is a valid semantic version that sorts the same way
+		"semver": func() (string, error) {
+			tag, err := c.GoModule(0)
+			return strings.TrimPrefix(tag, "v"), err
+		},
+		"gomodule": func(major uint) (string, error) {
+			return c.GoModule(major)
+		},
+		"pep440": c.PEP440,
+		"debian": c.Debian,
+		"rpm": func() string {
+			version, release := c.RPM()
+			return fmt.Sprintf("%s-%s", version, release)
+		},
+		"sortkey": c.SortKey,
+		// pad zero pads a number to the provided width, for instance
+		// `{{pad 2 .Minor}}`
+		"pad": func(width int, v interface{}) (string, error) {
+			n, err := strconv.ParseUint(fmt.Sprint(v), 10, 64)
+			if err != nil {
+				return "", fmt.Errorf("can't pad a value that isn't a number: %v", v)
+			}
+			return fmt.Sprintf("%0*d", width, n), nil
+		},
+		"upper":   strings.ToUpper,
+		"lower":   strings.ToLower,
+		"replace": strings.ReplaceAll,
+	}
+}
+
+// execute prints the output to w through the template, followed by a new line
+func (o output) execute(w io.Writer, c *calver.CalVer, text string) error {
+	t, err := template.New("calver").Funcs(funcs(c)).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	// nothing is printed unless the whole template could be executed
+	var b strings.Builder
+	if err := t.Execute(&b, o); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, b.String())
+	return nil
+}
+
+// printVersion prints the version to w through the template if there's one,
+// or in the output from the flags otherwise, with text being just the version
+// unless all the fields are asked for
+func printVersion(w io.Writer, c *calver.CalVer, printed, previous string, fields bool) error {
+	o := newOutput(c, printed, previous)
+
+	switch {
+	case *flagTemplate != "":
+		return o.execute(w, c, *flagTemplate)
+	case *flagOutput == "text" && !fields:
+		_, err := fmt.Fprintln(w, printed)
+		return err
+	default:
+		return o.print(w, *flagOutput)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/umayr/calver"
+)
+
+func TestPrintVersion(t *testing.T) {
+	defer func(o string) { *flagOutput = o }(*flagOutput)
+
+	c, err := calver.Parse("2020.12.20-1", "YYYY.MM.DD", "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		output   string
+		previous string
+		fields   bool
+		expected []string
+	}{
+		{"text", "2020.12.20", false, []string{"2020.12.20-1"}},
+		{"text", "", true, []string{
+			"version: 2020.12.20-1",
+			"format: YYYY.MM.DD",
+			"major: 2020",
+			"minor: 12",
+			"micro: 20",
+			"iteration: 1",
+			"prerelease: false",
+			"modifier: dev",
+			"date: 2020-12-20",
+			"previous: ",
+		}},
+		{"env", "2020.12.20", false, []string{
+			"CALVER_VERSION=2020.12.20-1",
+			"CALVER_FORMAT=YYYY.MM.DD",
+			"CALVER_MAJOR=2020",
+			"CALVER_MINOR=12",
+			"CALVER_MICRO=20",
+			"CALVER_ITERATION=1",
+			"CALVER_PRERELEASE=false",
+			"CALVER_MODIFIER=dev",
+			"CALVER_DATE=2020-12-20",
+			"CALVER_PREVIOUS=2020.12.20",
+		}},
+		{"yaml", "2020.12.20", false, []string{
+			`version: "2020.12.20-1"`,
+			`format: "YYYY.MM.DD"`,
+			`major: "2020"`,
+			`minor: "12"`,
+			`micro: "20"`,
+			`iteration: 1`,
+			`prerelease: false`,
+			`modifier: "dev"`,
+			`date: "2020-12-20"`,
+			`previous: "2020.12.20"`,
+		}},
+		{"json", "", false, []string{
+			`{`,
+			`  "version": "2020.12.20-1",`,
+			`  "format": "YYYY.MM.DD",`,
+			`  "major": "2020",`,
+			`  "minor": "12",`,
+			`  "micro": "20",`,
+			`  "iteration": 1,`,
+			`  "prerelease": false,`,
+			`  "modifier": "dev",`,
+			`  "date": "2020-12-20",`,
+			`  "previous": ""`,
+			`}`,
+		}},
+	}
+
+	for _, tc := range cases {
+		*flagOutput = tc.output
+
+		var b bytes.Buffer
+		if err := printVersion(&b, c, c.String(), tc.previous, tc.fields); err != nil {
+			t.Errorf("failed to print %s as %s: %s", c, tc.output, err)
+			continue
+		}
+
+		if expected := strings.Join(tc.expected, "\n") + "\n"; b.String() != expected {
+			t.Errorf("%s output should be:\n%s\nbut it was:\n%s", tc.output, expected, b.String())
+		}
+	}
+
+	*flagOutput = "xml"
+	if err := printVersion(&bytes.Buffer{}, c, c.String(), "", false); err == nil {
+		t.Error("invalid output should not be printed")
+	}
+}
+
+func TestPrintVersion_Template(t *testing.T) {
+	defer func(tmpl string) { *flagTemplate = tmpl }(*flagTemplate)
+
+	c, _ := calver.Parse("2020.12.20-dev.2", "YYYY.MM.DD", "dev")
+
+	cases := []struct {
+		template string
+		previous string
+		expected string
+	}{
+		{"{{.Version}} {{.Format}} {{.Major}} {{.Minor}} {{.Micro}}", "", "2020.12.20-dev.2 YYYY.MM.DD 2020 12 20"},
+		{"{{.Iteration}} {{.PreRelease}} {{.Modifier}} {{.Date}}", "", "2 true dev 2020-12-20"},
+		{"{{.Previous}}", "2020.12.20-1", "2020.12.20-1"},
+		{"{{.Previous}}", "", ""},
+		{"{{.Time.Year}} {{.Time.Month}}", "", "2020 December"},
+		{"app:{{.Major}}.{{pad 2 .Minor}}-{{semver}}", "", "app:2020.12-0.20201220.2-dev"},
+		{"{{upper .Modifier}} {{replace .Version \".\" \"_\"}}", "", "DEV 2020_12_20-dev_2"},
+	}
+
+	for _, tc := range cases {
+		*flagTemplate = tc.template
+
+		var b bytes.Buffer
+		if err := printVersion(&b, c, c.String(), tc.previous, false); err != nil {
+			t.Errorf("failed to execute %s: %s", tc.template, err)
+			continue
+		}
+
+		if actual := strings.TrimSuffix(b.String(), "\n"); actual != tc.expected {
+			t.Errorf("template %s should print %q but it was %q", tc.template, tc.expected, actual)
+		}
+	}
+
+	*flagTemplate = "{{pad 2 .Modifier}}"
+	var b bytes.Buffer
+	if err := printVersion(&b, c, c.String(), "", false); err == nil || b.Len() != 0 {
+		t.Errorf("template that fails should not print anything but it printed %q", b.String())
+	}
+}
@@ -0,0 +1,92 @@
+package calver
+
+import (
+	"strconv"
+)
+
+// Compare returns -1, 0 or 1 if the first version comes before, is the same
+// as or comes after the second one. Segments are compared in order, then the
+// iterations, with a prerelease coming right before the release with the same
+// iteration:
+//		2021.3.5-dev < 2021.3.5 < 2021.3.5-dev.1 < 2021.3.5-1 < 2021.3.6
+// Both versions are expected to have the same format
+func Compare(a, b *CalVer) int {
+	if r := a.format.compare(a.version, b.version); r != 0 {
+		return r
+	}
+
+	switch {
+	case a.increment < b.increment:
+		return -1
+	case a.increment > b.increment:
+		return 1
+	case a.pre && !b.pre:
+		return -1
+	case !a.pre && b.pre:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compare returns -1, 0 or 1 if the first version comes before, is the same
+// as or comes after the second one, segment by segment. Custom segments are
+// compared by their providers and the rest as numbers
+func (f *format) compare(a, b version) int {
+	for i, p := range f.parts() {
+		if a[i] == b[i] {
+			continue
+		}
+
+		if len(p) == 0 || a[i] == "" || b[i] == "" {
+			return compareStrings(a[i], b[i])
+		}
+
+		x, errX := p.split(a[i])
+		y, errY := p.split(b[i])
+		if errX != nil || errY != nil {
+			return compareStrings(a[i], b[i])
+		}
+
+		for j, s := range p {
+			if r := s.compare(x[j], y[j]); r != 0 {
+				return r
+			}
+		}
+	}
+
+	return 0
+}
+
+// compare returns how two values of the segment are ordered
+func (s segment) compare(a, b string) int {
+	if p := s.provider(); p != nil {
+		return p.Compare(a, b)
+	}
+
+	x, errX := strconv.ParseUint(a, 10, 64)
+	y, errY := strconv.ParseUint(b, 10, 64)
+	if errX != nil || errY != nil {
+		return compareStrings(a, b)
+	}
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,55 @@
+package calver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	ordered := []string{
+		"2021.3.5-dev",
+		"2021.3.5",
+		"2021.3.5-dev.1",
+		"2021.3.5-1",
+		"2021.3.5-10",
+		"2021.3.6",
+		"2021.10.1",
+	}
+
+	for i, x := range ordered {
+		a, _ := Parse(x, "YYYY.MM.DD", "dev")
+		for j, y := range ordered {
+			b, _ := Parse(y, "YYYY.MM.DD", "dev")
+
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+
+			if r := Compare(a, b); r != expected {
+				t.Errorf("comparison of %s and %s should be %d but it was %d", x, y, expected, r)
+			}
+		}
+	}
+}
+
+func TestCalVer_Period(t *testing.T) {
+	c, _ := Parse("2021.3", "YYYY.MM", "dev")
+
+	start, end, err := c.Period()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !start.Equal(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("period of %s should not be %s - %s", c, start, end)
+	}
+
+	c, _ = Parse("2021.0305.14", "YYYY.0M0D.0H", "dev")
+	start, end, _ = c.Period()
+	if !start.Equal(time.Date(2021, 3, 5, 14, 0, 0, 0, time.UTC)) || end.Sub(start) != time.Hour {
+		t.Errorf("period of %s should not be %s - %s", c, start, end)
+	}
+}
@@ -0,0 +1,241 @@
+package calver
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// year returns the year a year segment stands for
+func (s segment) year(raw string) (int, error) {
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("provided string doesn't match the format segment: %s", s.String())
+	}
+
+	switch s {
+	case segmentFullYear:
+		return v, nil
+	case segmentShortYear, segmentPaddedYear:
+		return 2000 + v, nil
+	default:
+		return 0, fmt.Errorf("not a year segment: %s", s.String())
+	}
+}
+
+// matches tells if the segment would render the provided value for the time
+func (s segment) matches(t time.Time, cal Calendar, raw string) bool {
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return false
+	}
+
+	c, err := strconv.Atoi(s.conv(t, cal))
+	if err != nil {
+		return false
+	}
+
+	return v == c
+}
+
+// step returns the length of the shortest period the segment tells apart,
+// which is a day for all but the segments for the time of the day
+func (s segment) step() time.Duration {
+	switch s {
+	case segmentShortHour, segmentPaddedHour:
+		return time.Hour
+	case segmentShortMinute, segmentPaddedMinute:
+		return time.Minute
+	case segmentShortSecond, segmentPaddedSecond:
+		return time.Second
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// dates returns all the moments that the version stands for, along with how
+// long each of them lasts. For instance every day of March 2021 for `2021.3`
+// in `YYYY.MM`, or the hour from 14:00 on 5th of March 2021 for
+// `2021.0305.14` in `YYYY.0M0D.0H`. It requires the format to have a year
+// segment, or a custom one with a range, since otherwise there would be no
+// end to it
+func (c *CalVer) dates() ([]time.Time, time.Duration, error) {
+	if c.major == "" {
+		return nil, 0, fmt.Errorf("there hasn't been any release for the format: %s", c.format)
+	}
+
+	var (
+		segs       []segment
+		vals       []string
+		start, end time.Time
+	)
+	for i, raw := range []string{c.major, c.minor, c.micro} {
+		p := c.format.parts()[i]
+		if len(p) == 0 || p[0] == segmentMicro {
+			continue
+		}
+
+		v, err := p.split(raw)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// custom segments only tell about the date if they have a range
+		for j, s := range p {
+			if s.provider() == nil {
+				segs = append(segs, s)
+				vals = append(vals, v[j])
+				continue
+			}
+
+			r, ok := s.provider().(SegmentRange)
+			if !ok {
+				continue
+			}
+
+			from, to, err := r.Range(v[j])
+			if err != nil {
+				return nil, 0, err
+			}
+
+			start, end = overlap(start, end, from, to)
+		}
+	}
+
+	year := -1
+	step := 24 * time.Hour
+	for i, s := range segs {
+		if y, err := s.year(vals[i]); err == nil && year < 0 {
+			year = y
+		}
+
+		if s.step() < step {
+			step = s.step()
+		}
+	}
+
+	if year >= 0 {
+		start, end = overlap(start, end, c.format.calendar.start(year), c.format.calendar.start(year+1))
+	}
+
+	if start.IsZero() {
+		return nil, 0, fmt.Errorf("format doesn't have a year segment: %s", c.format)
+	}
+
+	match := func(t time.Time, clock bool) bool {
+		for i, s := range segs {
+			if s.clock() == clock && !s.matches(t, c.format.calendar, vals[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// the time of the day doesn't depend on the date so the matching offsets
+	// are only worked out once
+	var offsets []time.Duration
+	for o := time.Duration(0); o < 24*time.Hour; o += step {
+		if match(start.Add(o), true) {
+			offsets = append(offsets, o)
+		}
+	}
+
+	var dates []time.Time
+	for t := start; t.Before(end); t = t.AddDate(0, 0, 1) {
+		if !match(t, false) {
+			continue
+		}
+
+		for _, o := range offsets {
+			dates = append(dates, t.Add(o))
+		}
+	}
+
+	if len(dates) == 0 {
+		return nil, 0, fmt.Errorf("version doesn't stand for any date: %s", c)
+	}
+
+	return dates, step, nil
+}
+
+// Period returns when the period the version stands for starts and when the
+// next one does, for instance from 1st of March 2021 until 1st of April 2021
+// for `2021.3` in `YYYY.MM`. Segments that don't follow each other, like in
+// `YYYY.DD`, make the period span all the moments in between
+func (c *CalVer) Period() (time.Time, time.Time, error) {
+	dates, step, err := c.dates()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end := dates[len(dates)-1].Add(step)
+	if step == 24*time.Hour {
+		end = dates[len(dates)-1].AddDate(0, 0, 1)
+	}
+
+	return dates[0], end, nil
+}
+
+// overlap returns the period where both of the provided ones overlap, a zero
+// start means the first period is yet to be known
+func overlap(start, end, from, to time.Time) (time.Time, time.Time) {
+	if start.IsZero() {
+		return from, to
+	}
+
+	if from.After(start) {
+		start = from
+	}
+
+	if to.Before(end) {
+		end = to
+	}
+
+	return start, end
+}
+
+// Convert reinterprets the date of the version in another format and returns
+// it as a new CalVer, for instance:
+//		2021.03.05 (YYYY.0M.0D)	->	21.3 (YY.MM)
+//		2021.03.05 (YYYY.0M.0D)	->	21.09 (YY.0W)
+// A version can only be converted into a format that is as coarse or coarser,
+// since otherwise the missing segments would have to be made up. The
+// prerelease is always kept, but the iteration is only kept when both formats
+// stand for the same period, as it wouldn't mean much otherwise
+func (c *CalVer) Convert(toFormat string) (*CalVer, error) {
+	f, err := newFormat(toFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	days, step, err := c.dates()
+	if err != nil {
+		return nil, err
+	}
+
+	f.calendar = c.format.calendar
+
+	v := f.conv(days[0])
+	for _, t := range days[1:] {
+		if !v.eq(f.conv(t)) {
+			return nil, fmt.Errorf("version %s is too coarse to be converted into the format: %s", c, f)
+		}
+	}
+
+	n := &CalVer{modifier: c.modifier, pre: c.pre, format: f, version: v, iter: c.iter, monotonic: c.monotonic, strict: c.strict}
+	n.major, n.minor, n.micro = v.spread()
+
+	if c.increment > 0 {
+		// both versions stand for the same period only if they start at the
+		// same time and last as long
+		target, targetStep, err := n.dates()
+		if err != nil || !target[0].Equal(days[0]) || time.Duration(len(target))*targetStep != time.Duration(len(days))*step {
+			return nil, fmt.Errorf("iteration of version %s would be lost in the format: %s", c, f)
+		}
+
+		n.increment = c.increment
+	}
+
+	return n, nil
+}
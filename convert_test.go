@@ -0,0 +1,105 @@
+package calver
+
+import (
+	"testing"
+)
+
+func TestCalVer_Convert(t *testing.T) {
+	cases := []struct {
+		raw      string
+		from     string
+		to       string
+		expected string
+	}{
+		{"2021.03.05", "YYYY.0M.0D", "YY.MM", "21.3"},
+		{"2021.03.05", "YYYY.0M.0D", "YY.0W", "21.09"},
+		{"2021.03.05-dev", "YYYY.0M.0D", "YY.0W", "21.09-dev"},
+		{"2021.03.05-dev.2", "YYYY.0M.0D", "YY.MM.DD", "21.3.5-dev.2"},
+		{"2021.03.05-3", "YYYY.0M.0D", "0Y.0M.0D", "21.03.05-3"},
+		{"2021.3.5", "YYYY.MM.DD", "WW.DD", "9.5"},
+		{"2020.2", "YYYY.MM", "YY.0M", "20.02"},
+		{"2021.1", "YYYY.WW", "YYYY.MM", "2021.1"},
+		{"2021.53", "YYYY.WW", "YYYY.0M.0W", "2021.01.53"},
+	}
+
+	for _, tc := range cases {
+		c, err := Parse(tc.raw, tc.from, "")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", tc.raw, err)
+		}
+
+		n, err := c.Convert(tc.to)
+		if err != nil {
+			t.Fatalf("failed to convert %s into %s: %s", tc.raw, tc.to, err)
+		}
+
+		if n.String() != tc.expected {
+			t.Errorf("converted version of %s should be %s but it was %s", tc.raw, tc.expected, n.String())
+		}
+	}
+
+	invalid := []struct {
+		raw  string
+		from string
+		to   string
+	}{
+		{"2021.3", "YYYY.MM", "YYYY.MM.DD"},
+		{"2021.13", "YYYY.WW", "YYYY.MM"},
+		{"2021.03.05-3", "YYYY.0M.0D", "YY.MM"},
+		{"3.5", "MM.DD", "YY.MM"},
+		{"2021.3", "YYYY.MM", "YYYY.XX"},
+	}
+
+	for _, tc := range invalid {
+		c, err := Parse(tc.raw, tc.from, "")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", tc.raw, err)
+		}
+
+		if _, err := c.Convert(tc.to); err == nil {
+			t.Errorf("version %s in %s should not be converted into %s", tc.raw, tc.from, tc.to)
+		}
+	}
+}
+
+func TestCalVer_ConvertClock(t *testing.T) {
+	c, _ := Parse("2021.0305.1405-2", "YYYY.0M0D.0H0m", "")
+
+	n, err := c.Convert("YY.MM.DD")
+	if err == nil {
+		t.Errorf("iteration of %s should not be kept in a coarser format but got %s", c, n)
+	}
+
+	c, _ = Parse("2021.0305.1405", "YYYY.0M0D.0H0m", "")
+
+	n, err = c.Convert("YY.MM.DD")
+	if err != nil {
+		t.Fatalf("failed to convert %s: %s", c, err)
+	}
+
+	if n.String() != "21.3.5" {
+		t.Errorf("converted version of %s should be 21.3.5 but it was %s", c, n)
+	}
+
+	d, _ := Parse("2021.03.05-2", "YYYY.0M.0D", "")
+	if _, err := d.Convert("YYYY.0M0D.0H"); err == nil {
+		t.Errorf("version %s should not be converted into a finer format", d)
+	}
+}
+
+func TestCalVer_ConvertOptions(t *testing.T) {
+	c, _ := Parse("2021.3.5-009", "YYYY.MM.DD", "dev", WithIteration(Iteration{Width: 3}), WithMonotonic(MonotonicKeep))
+
+	n, err := c.Convert("YY.MM.DD")
+	if err != nil {
+		t.Fatalf("failed to convert %s: %s", c, err)
+	}
+
+	if n.String() != "21.3.5-009" {
+		t.Errorf("converted version of %s should be 21.3.5-009 but it was %s", c, n)
+	}
+
+	if n.monotonic != MonotonicKeep {
+		t.Errorf("monotonic policy of %s should be kept but it was %s", c, n.monotonic)
+	}
+}
@@ -0,0 +1,72 @@
+package calver
+
+import (
+	"strings"
+)
+
+// Match is a version found in a text, at text[Start:End]
+type Match struct {
+	Start   int
+	End     int
+	Version *CalVer
+}
+
+// longest is the most a version found in a text could take, which is far
+// more than any format would need
+const longest = 64
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// isVersionByte tells if the byte could be a part of a version, including
+// the modifier and the separators
+func isVersionByte(b byte) bool {
+	return isDigit(b) || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || strings.IndexByte(".-_+", b) >= 0
+}
+
+// Find returns all the versions of the format in the text along with where
+// they are, for instance in log lines, tags with a `v` in front or file names
+// like `app-2021.03.05-dev.2-linux.tar.gz`. Versions start with a digit that
+// isn't a part of another number, and the longest one is taken when there's
+// a choice, so that the iteration isn't left out
+func Find(text, format, modifier string, opts ...Option) ([]Match, error) {
+	if _, err := New(format, modifier, opts...); err != nil {
+		return nil, err
+	}
+
+	var matches []Match
+	for i := 0; i < len(text); i++ {
+		// a `.` only joins numbers, so there could be a version after one
+		// in file names like `app.2021.03.05.tar.gz`
+		if !isDigit(text[i]) || (i > 0 && isDigit(text[i-1])) || (i > 1 && text[i-1] == '.' && isDigit(text[i-2])) {
+			continue
+		}
+
+		end := i
+		for end < len(text) && end-i < longest && isVersionByte(text[end]) {
+			end++
+		}
+
+		for j := end; j > i; j-- {
+			// a version can't end with a separator or cut a number short
+			if strings.IndexByte(".-_+", text[j-1]) >= 0 {
+				continue
+			}
+			if j < len(text) && isDigit(text[j]) && isDigit(text[j-1]) {
+				continue
+			}
+
+			c, err := Parse(text[i:j], format, modifier, opts...)
+			if err != nil {
+				continue
+			}
+
+			matches = append(matches, Match{Start: i, End: j, Version: c})
+			i = j - 1
+			break
+		}
+	}
+
+	return matches, nil
+}
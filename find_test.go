@@ -0,0 +1,44 @@
+package calver
+
+import (
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	cases := []struct {
+		text, format string
+		expected     []string
+	}{
+		{"app-2021.03.05-dev.2-linux.tar.gz", "YYYY.0M.0D", []string{"2021.03.05-dev.2"}},
+		{"released v2021.3.5-1 and v2021.3.6", "YYYY.MM.DD", []string{"2021.3.5-1", "2021.3.6"}},
+		{"[12:04:05] deploying 2021.03 to 10.0.0.1", "YYYY.0M", []string{"2021.03"}},
+		{"build 12021.03.05 isn't one", "YYYY.0M.0D", nil},
+		{"2021.03.05.tar.gz", "YYYY.0M.0D", []string{"2021.03.05"}},
+		{"app.2021.03.05.tar.gz", "YYYY.0M.0D", []string{"2021.03.05"}},
+		{"release.2021.3.5", "YYYY.MM.DD", []string{"2021.3.5"}},
+		{"lib-1.2021.03.05", "YYYY.0M.0D", nil},
+		{"no versions here", "YYYY.0M.0D", nil},
+	}
+
+	for _, tc := range cases {
+		matches, err := Find(tc.text, tc.format, "dev")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(matches) != len(tc.expected) {
+			t.Errorf("matches in %q should be %v but they were %v", tc.text, tc.expected, matches)
+			continue
+		}
+
+		for i, m := range matches {
+			if tc.text[m.Start:m.End] != tc.expected[i] || m.Version.String() != tc.expected[i] {
+				t.Errorf("match in %q should be %s but it was %s at %d:%d", tc.text, tc.expected[i], m.Version, m.Start, m.End)
+			}
+		}
+	}
+
+	if _, err := Find("2021.03", "YYYY.XX", "dev"); err == nil {
+		t.Error("invalid format should not be accepted")
+	}
+}
@@ -0,0 +1,134 @@
+package calver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GoModule returns the version as a tag that Go modules accept. Since Go
+// modules only support `vMAJOR.MINOR.PATCH` tags and require a path suffix
+// for majors above 1, all the segments are packed into the minor and the
+// iteration goes into the patch, with the provided major (either 0 or 1) in
+// front, for instance:
+//		2020.12.20			->	v0.20201220.0
+//		2020.12.20-2		->	v0.20201220.2
+//		2020.12.20-dev		->	v0.20201220.0-dev
+//		2020.12.20-dev.2	->	v0.20201220.2-dev
+func (c *CalVer) GoModule(major uint) (string, error) {
+	if major > 1 {
+		return "", fmt.Errorf("go module major version could only be 0 or 1: %d", major)
+	}
+
+	if c.major == "" {
+		return "", fmt.Errorf("there hasn't been any release for the format: %s", c.format)
+	}
+
+	minor := ""
+	parts := c.format.parts()
+	for _, p := range parts {
+		for _, s := range p {
+			if s.provider() != nil {
+				return "", fmt.Errorf("custom segments can't be a part of a go module tag: %s", s)
+			}
+		}
+	}
+
+	for i, raw := range []string{c.major, c.minor, c.micro} {
+		if len(parts[i]) == 0 || parts[i][0] == segmentMicro {
+			continue
+		}
+
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return "", fmt.Errorf("invalid version segment: %s", raw)
+		}
+
+		minor += fmt.Sprintf("%0*d", parts[i].width(), v)
+	}
+
+	minor = strings.TrimLeft(minor, "0")
+	if minor == "" {
+		minor = "0"
+	}
+
+	v := fmt.Sprintf("v%d.%s.%d", major, minor, c.increment)
+
+	if c.pre {
+		v += fmt.Sprintf("-%s", c.modifier)
+	}
+
+	return v, nil
+}
+
+// ParseGoModule takes a tag produced by GoModule and returns the CalVer
+// instance for the provided format and modifier
+func ParseGoModule(raw, format, modifier string, opts ...Option) (*CalVer, error) {
+	c, err := New(format, modifier, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	errBadTag := fmt.Errorf("provided string isn't a valid go module tag for the format: %s", c.format)
+
+	v := strings.TrimPrefix(raw, "v")
+	if v == raw {
+		return nil, errBadTag
+	}
+
+	if strings.HasSuffix(v, fmt.Sprintf("-%s", c.modifier)) {
+		c.pre = true
+		v = strings.TrimSuffix(v, fmt.Sprintf("-%s", c.modifier))
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 || (parts[0] != "0" && parts[0] != "1") {
+		return nil, errBadTag
+	}
+
+	inc, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, errBadTag
+	}
+
+	c.increment = inc
+
+	// the segments are unpacked from the right since only the first one
+	// could have lost its leading zeros
+	minor := parts[1]
+	segs := c.format.parts()
+	nums := make([]string, 3)
+	for i := len(segs) - 1; i >= 0; i-- {
+		if len(segs[i]) == 0 {
+			continue
+		}
+
+		// micro is already in the patch
+		if segs[i][0] == segmentMicro {
+			nums[i] = parts[2]
+			continue
+		}
+
+		w := segs[i].width()
+		if i == 0 || w > len(minor) {
+			w = len(minor)
+		}
+
+		nums[i] = minor[len(minor)-w:]
+		minor = minor[:len(minor)-w]
+	}
+
+	if minor != "" || nums[0] == "" {
+		return nil, errBadTag
+	}
+
+	if len(segs[2]) == 0 {
+		nums = nums[:2]
+	}
+
+	if err := c.fill(strings.Join(nums, ".")); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,67 @@
+package calver
+
+import (
+	"testing"
+)
+
+func TestCalVer_GoModule(t *testing.T) {
+	cases := []struct {
+		format   string
+		raw      string
+		expected string
+	}{
+		{"YYYY.0M.0D", "2007.02.05", "v0.20070205.0"},
+		{"YYYY.0M.0D", "2007.02.05-2", "v0.20070205.2"},
+		{"YYYY.0M.0D", "2007.02.05-dev", "v0.20070205.0-dev"},
+		{"YYYY.0M.0D", "2007.02.05-dev.2", "v0.20070205.2-dev"},
+		{"YYYY.MM.DD", "2007.2.5", "v0.20070205.0"},
+		{"YY.0W", "7.06", "v0.706.0"},
+		{"0Y.0M", "07.02-3", "v0.702.3"},
+		{"WW.DD", "1.5", "v0.105.0"},
+	}
+
+	for _, tc := range cases {
+		c, err := Parse(tc.raw, tc.format, "")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", tc.raw, err)
+		}
+
+		tag, err := c.GoModule(0)
+		if err != nil {
+			t.Fatalf("failed to create go module tag for %s: %s", tc.raw, err)
+		}
+
+		if tag != tc.expected {
+			t.Errorf("go module tag of %s should be %s but it was %s", tc.raw, tc.expected, tag)
+		}
+
+		p, err := ParseGoModule(tc.expected, tc.format, "")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", tc.expected, err)
+		}
+
+		if p.String() != tc.raw {
+			t.Errorf("parsed go module tag %s should be %s but it was %s", tc.expected, tc.raw, p.String())
+		}
+	}
+
+	c, _ := Parse("2007.2.5-1", "YYYY.MM.DD", "")
+	if tag, _ := c.GoModule(1); tag != "v1.20070205.1" {
+		t.Errorf("go module tag should be v1.20070205.1 but it was %s", tag)
+	}
+
+	if _, err := c.GoModule(2); err == nil {
+		t.Error("go module tag should not support majors above 1")
+	}
+
+	n, _ := New("YYYY.MM.DD", "")
+	if _, err := n.GoModule(0); err == nil {
+		t.Error("go module tag should not be created without a release")
+	}
+
+	for _, raw := range []string{"0.20070205.0", "v2.20070205.0", "v0.20071305.0", "v0.205.0", "v0.20070205", "v0.20070205.x"} {
+		if _, err := ParseGoModule(raw, "YYYY.MM.DD", ""); err == nil {
+			t.Errorf("invalid go module tag %s should not be parsed", raw)
+		}
+	}
+}
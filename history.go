@@ -0,0 +1,183 @@
+package calver
+
+import (
+	"fmt"
+	"time"
+)
+
+// Iterator walks through a sequence of versions, for instance:
+//		it, _ := Versions("YYYY.0M.0D", "dev", from, to)
+//		for it.Next() {
+//			fmt.Println(it.Version())
+//		}
+type Iterator struct {
+	next    func() (*CalVer, error)
+	version *CalVer
+	err     error
+}
+
+// Next moves to the next version and tells if there was one
+func (it *Iterator) Next() bool {
+	if it.err != nil || it.next == nil {
+		return false
+	}
+
+	it.version, it.err = it.next()
+	if it.version == nil {
+		it.next = nil
+		return false
+	}
+
+	return true
+}
+
+// Version returns the version Next moved to
+func (it *Iterator) Version() *CalVer {
+	return it.version
+}
+
+// Err returns the error that stopped the iterator, if any
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// step returns the length of the shortest period the format tells apart
+func (f *format) step() time.Duration {
+	step := 24 * time.Hour
+	for _, p := range f.parts() {
+		for _, s := range p {
+			if s.step() < step {
+				step = s.step()
+			}
+		}
+	}
+
+	return step
+}
+
+// at returns the first release of the format at the provided time
+func (f *format) at(t time.Time, modifier string) *CalVer {
+	c := &CalVer{modifier: modifier, format: f, version: f.conv(t)}
+	c.major, c.minor, c.micro = c.version.spread()
+
+	return c
+}
+
+// Versions returns an iterator over every version the format goes through
+// from one time until another, without any iteration or prerelease. For
+// instance every release in the last 90 days of a daily format:
+//		Versions("YYYY.0M.0D", "dev", time.Now().AddDate(0, 0, -90), time.Now())
+func Versions(format, modifier string, from, to time.Time, opts ...Option) (*Iterator, error) {
+	c, err := New(format, modifier, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.Before(from) {
+		return nil, fmt.Errorf("period ends before it starts: %s - %s", from, to)
+	}
+
+	return c.versions(from, to), nil
+}
+
+// versions returns an iterator over every version the format of the CalVer
+// goes through from one time until another
+func (c *CalVer) versions(from, to time.Time) *Iterator {
+	step := c.format.step()
+
+	var prev *CalVer
+	t := from
+	return &Iterator{next: func() (*CalVer, error) {
+		for t.Before(to) {
+			if err := c.format.renders(t); err != nil {
+				return nil, err
+			}
+
+			v := c.format.at(t, c.modifier)
+			v.iter, v.monotonic = c.iter, c.monotonic
+
+			if step == 24*time.Hour {
+				t = t.AddDate(0, 0, 1)
+			} else {
+				t = t.Add(step)
+			}
+
+			if prev == nil || !prev.version.eq(v.version) {
+				prev = v
+				return v, nil
+			}
+		}
+
+		return nil, nil
+	}}
+}
+
+// Iterations returns an iterator over the releases of the period the version
+// stands for, from the first one up to the version itself, for instance:
+//		2020.12.20-dev.3	->	2020.12.20, 2020.12.20-1, 2020.12.20-2, 2020.12.20-dev.3
+func (c *CalVer) Iterations() *Iterator {
+	inc := uint64(0)
+	return &Iterator{next: func() (*CalVer, error) {
+		if c.major == "" || inc > c.increment {
+			return nil, nil
+		}
+
+		v := *c
+		if inc < c.increment {
+			v.increment, v.pre = inc, false
+		}
+		inc++
+
+		return &v, nil
+	}}
+}
+
+// Previous returns the release that comes right before the version, which is
+// the one with the previous iteration in the same period. For the first one
+// of a period it's the first release of the previous period, since there's no
+// way to tell how many iterations that had:
+//		2020.12.20-2		->	2020.12.20-1
+//		2020.12.20-dev.2	->	2020.12.20-1
+//		2020.12.20			->	2020.12.19
+// It requires the format to have a year segment, and custom segments to have
+// a range, to tell what the previous period is
+func (c *CalVer) Previous() (*CalVer, error) {
+	if c.major == "" {
+		return nil, fmt.Errorf("there hasn't been any release for the format: %s", c.format)
+	}
+
+	if c.increment > 0 {
+		v := *c
+		v.increment--
+		v.pre, v.err = false, nil
+
+		return &v, nil
+	}
+
+	for _, p := range c.format.parts() {
+		for _, s := range p {
+			if _, ok := s.provider().(SegmentRange); s.provider() != nil && !ok {
+				return nil, fmt.Errorf("previous value can't be told for the segment: %s", s)
+			}
+		}
+	}
+
+	days, step, err := c.dates()
+	if err != nil {
+		return nil, err
+	}
+
+	t := days[0].Add(-step)
+	if step == 24*time.Hour {
+		t = days[0].AddDate(0, 0, -1)
+	}
+
+	if err := c.format.renders(t); err != nil {
+		return nil, err
+	}
+
+	v := c.format.at(t, c.modifier)
+	v.iter, v.monotonic = c.iter, c.monotonic
+
+	return v, nil
+}
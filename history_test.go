@@ -0,0 +1,116 @@
+package calver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalVer_Previous(t *testing.T) {
+	cases := []struct {
+		raw, format, expected string
+	}{
+		{"2020.12.20-2", "YYYY.MM.DD", "2020.12.20-1"},
+		{"2020.12.20-1", "YYYY.MM.DD", "2020.12.20"},
+		{"2020.12.20-dev.2", "YYYY.MM.DD", "2020.12.20-1"},
+		{"2020.12.20", "YYYY.MM.DD", "2020.12.19"},
+		{"2020.12.20-dev", "YYYY.MM.DD", "2020.12.19"},
+		{"2021.1.1", "YYYY.MM.DD", "2020.12.31"},
+		{"2021.03", "YYYY.0M", "2021.02"},
+		{"21.1", "YY.Q", "20.4"},
+		{"2021.3.4", "YYYY.MM.MICRO", "2021.3.3"},
+		{"2021.3.0", "YYYY.MM.MICRO", "2021.2.0"},
+		{"2021.0305.00", "YYYY.0M0D.0H", "2021.0304.23"},
+	}
+
+	for _, tc := range cases {
+		c, err := Parse(tc.raw, tc.format, "dev")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", tc.raw, err)
+		}
+
+		p, err := c.Previous()
+		if err != nil {
+			t.Errorf("failed to parse %s: %s", tc.raw, err)
+			continue
+		}
+
+		if p.String() != tc.expected {
+			t.Errorf("previous version of %s should be %s but it was %s", tc.raw, tc.expected, p)
+		}
+	}
+
+	c, _ := New("YYYY.MM.DD", "dev")
+	if _, err := c.Previous(); err == nil {
+		t.Error("previous version of an empty version should not be told")
+	}
+
+	c, _ = Parse("3.5", "MM.DD", "dev")
+	if _, err := c.Previous(); err == nil {
+		t.Error("previous version of a format without a year should not be told")
+	}
+}
+
+func TestVersions(t *testing.T) {
+	from := time.Date(2021, 1, 30, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC)
+
+	it, err := Versions("YYYY.0M", "dev", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual []string
+	for it.Next() {
+		actual = append(actual, it.Version().String())
+	}
+
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+
+	expected := []string{"2021.01", "2021.02", "2021.03", "2021.04"}
+	if len(actual) != len(expected) {
+		t.Fatalf("versions should be %v but they were %v", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("version should be %s but it was %s", expected[i], actual[i])
+		}
+	}
+
+	it, _ = Versions("YYYY.0M.0D", "dev", to.AddDate(0, 0, -90), to)
+	n := 0
+	for it.Next() {
+		n++
+	}
+
+	if n != 90 {
+		t.Errorf("daily versions should be 90 but they were %d", n)
+	}
+
+	if _, err := Versions("YYYY.0M", "dev", to, from); err == nil {
+		t.Error("period that ends before it starts should not be accepted")
+	}
+}
+
+func TestCalVer_Iterations(t *testing.T) {
+	c, _ := Parse("2020.12.20-dev.3", "YYYY.MM.DD", "dev")
+
+	var actual []string
+	it := c.Iterations()
+	for it.Next() {
+		actual = append(actual, it.Version().String())
+	}
+
+	expected := []string{"2020.12.20", "2020.12.20-1", "2020.12.20-2", "2020.12.20-dev.3"}
+	if len(actual) != len(expected) {
+		t.Fatalf("versions should be %v but they were %v", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("version should be %s but it was %s", expected[i], actual[i])
+		}
+	}
+}
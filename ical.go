@@ -0,0 +1,85 @@
+package calver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// icalText escapes the characters that have a meaning in iCalendar text
+var icalText = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// icalFold ends the line and folds it into lines of at most 75 octets, with
+// the ones after the first starting with a space, as RFC 5545 requires. It
+// never splits a character in the middle
+func icalFold(s string) string {
+	var b strings.Builder
+	for limit := 75; len(s) > limit; limit = 74 {
+		i := limit
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+
+		b.WriteString(s[:i])
+		b.WriteString("\r\n ")
+		s = s[i:]
+	}
+
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// ICalendar writes the planned releases as an iCalendar (RFC 5545) that mail
+// clients could subscribe to, with an all day event for each release titled
+// after its version. The product, usually a domain like `example.com`, tells
+// the events of different projects apart in the calendars they end up in. With
+// freeze being more than zero, there is also an event that many days before
+// each release for the code freeze
+func ICalendar(w io.Writer, product string, planned []Planned, freeze int) error {
+	if product == "" {
+		return fmt.Errorf("a product is required to tell the events apart")
+	}
+
+	if freeze < 0 {
+		return fmt.Errorf("invalid number of days for the freeze: %d", freeze)
+	}
+
+	b := bufio.NewWriter(w)
+	line := func(format string, a ...interface{}) {
+		b.WriteString(icalFold(fmt.Sprintf(format, a...)))
+	}
+
+	stamp := now().UTC().Format("20060102T150405Z")
+	event := func(uid, summary string, p Planned, days int) {
+		day := p.Date.AddDate(0, 0, -days)
+
+		line("BEGIN:VEVENT")
+		line("UID:%s", icalText.Replace(uid))
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART;VALUE=DATE:%s", day.Format("20060102"))
+		line("DTEND;VALUE=DATE:%s", day.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:%s", icalText.Replace(summary))
+		line("END:VEVENT")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//%s//calver//EN", icalText.Replace(product))
+	line("CALSCALE:GREGORIAN")
+
+	for _, p := range planned {
+		v := p.Version.String()
+		if freeze > 0 {
+			event(fmt.Sprintf("freeze-%s@%s", v, product), fmt.Sprintf("Freeze for %s", v), p, freeze)
+		}
+
+		event(fmt.Sprintf("release-%s@%s", v, product), v, p, 0)
+	}
+
+	line("END:VCALENDAR")
+
+	return b.Flush()
+}
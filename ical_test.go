@@ -0,0 +1,92 @@
+package calver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestICalendar(t *testing.T) {
+	from := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	planned, _ := Schedule("YYYY.0M", Monthly(1, time.Tuesday), from, from.AddDate(0, 1, 0))
+
+	var b bytes.Buffer
+	if err := ICalendar(&b, "example.com", planned, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//example.com//calver//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VEVENT",
+		"UID:freeze-2021.04@example.com",
+		"DTSTAMP:20070205T000000Z",
+		"DTSTART;VALUE=DATE:20210403",
+		"DTEND;VALUE=DATE:20210404",
+		"SUMMARY:Freeze for 2021.04",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:release-2021.04@example.com",
+		"DTSTAMP:20070205T000000Z",
+		"DTSTART;VALUE=DATE:20210406",
+		"DTEND;VALUE=DATE:20210407",
+		"SUMMARY:2021.04",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	if b.String() != expected {
+		t.Errorf("calendar should be:\n%s\nbut it was:\n%s", expected, b.String())
+	}
+
+	b.Reset()
+	ICalendar(&b, "example.com", planned, 0)
+	if strings.Contains(b.String(), "Freeze") {
+		t.Error("calendar without a freeze should not have freeze events")
+	}
+
+	if err := ICalendar(&b, "example.com", planned, -1); err == nil {
+		t.Error("negative freeze should not be accepted")
+	}
+
+	if err := ICalendar(&b, "", planned, 0); err == nil {
+		t.Error("calendar without a product should not be written")
+	}
+}
+
+func TestICalendar_Fold(t *testing.T) {
+	from := time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC)
+	planned, _ := Schedule("YYYY.0M0D", Daily(), from, from.AddDate(0, 0, 1))
+
+	var b bytes.Buffer
+	product := "releases.some-rather-long-product-name.engineering.example.com"
+	if err := ICalendar(&b, product, planned, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, l := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line should be at most 75 octets but it was %d: %s", len(l), l)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(b.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "\r\nUID:freeze-2021.0305@"+product+"\r\n") {
+		t.Errorf("unfolded calendar should have the UID of the freeze but it was:\n%s", unfolded)
+	}
+
+	if !strings.Contains(unfolded, "\r\nPRODID:-//"+product+"//calver//EN\r\n") {
+		t.Errorf("unfolded calendar should have the PRODID but it was:\n%s", unfolded)
+	}
+
+	for _, l := range strings.Split(icalFold(strings.Repeat("é", 40)), "\r\n") {
+		if !utf8.ValidString(l) || len(l) > 75 {
+			t.Errorf("folded line should not split a character but it was %q", l)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package calver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format is a textual CalVer format built out of the segments, for instance
+// `YYYY.0M.0D`
+type Format string
+
+// candidate is a format being considered by Infer along with how well it fits
+// the provided samples
+type candidate struct {
+	format *format
+	score  float64
+}
+
+// limit returns the highest value a segment could hold, it's used to tell how
+// tightly a segment fits the values seen in the samples
+func (s segment) limit() int {
+	switch s {
+	case segmentShortYear, segmentPaddedYear:
+		return 99
+	case segmentShortMonth, segmentPaddedMonth:
+		return 12
+	case segmentShortWeek, segmentPaddedWeek:
+		return 53
+	case segmentShortDay, segmentPaddedDay:
+		return 31
+	case segmentShortHour, segmentPaddedHour:
+		return 23
+	case segmentShortMinute, segmentPaddedMinute, segmentShortSecond, segmentPaddedSecond:
+		return 59
+	case segmentShortDayOfYear, segmentPaddedDayOfYear:
+		return 366
+	case segmentQuarter:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// granularity returns the length of the period a segment stands for, from
+// year to day, so that formats in chronological order can be preferred
+func (s segment) granularity() int {
+	switch s {
+	case segmentFullYear, segmentShortYear, segmentPaddedYear:
+		return 1
+	case segmentShortMonth, segmentPaddedMonth, segmentShortWeek, segmentPaddedWeek, segmentQuarter:
+		return 2
+	case segmentShortDay, segmentPaddedDay, segmentShortDayOfYear, segmentPaddedDayOfYear:
+		return 3
+	case segmentShortHour, segmentPaddedHour:
+		return 4
+	case segmentShortMinute, segmentPaddedMinute:
+		return 5
+	case segmentShortSecond, segmentPaddedSecond:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// fits tells if the raw version matches the format exactly, meaning that it'd
+// be rendered the same way by the format once parsed
+func (f *format) fits(raw string) bool {
+	c := &CalVer{format: f}
+	if err := c.set(raw); err != nil || c.core() != raw {
+		return false
+	}
+
+	segs := f.parts()
+	for i, v := range strings.Split(raw, ".") {
+		n, err := strconv.Atoi(v)
+		if err != nil || segs[i].itoa(n) != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Infer takes a list of existing versions and returns all the formats that
+// every one of them matches, best candidate first. Candidates are ranked by
+// how tightly the segments fit the values seen in the samples, so that for
+// instance a value of 13 rules out months and 53 suggests weeks, and by
+// whether the segments are in chronological order
+func Infer(samples []string) ([]Format, error) {
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("at least one version is required to infer the format")
+	}
+
+	cores := make([]string, len(samples))
+	for i, s := range samples {
+		cores[i] = strings.Split(strings.TrimSpace(s), "-")[0]
+	}
+
+	parts := strings.Count(cores[0], ".") + 1
+	if parts < 2 || parts > 3 {
+		return nil, fmt.Errorf("unable to infer the format from: %s", samples[0])
+	}
+
+	var candidates []candidate
+	for _, f := range formats(parts) {
+		matches := f.plausible(cores)
+		for _, c := range cores {
+			if !matches || !f.fits(c) {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			candidates = append(candidates, candidate{f, f.score(cores)})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("unable to infer the format from: %s", strings.Join(samples, ", "))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	inferred := make([]Format, len(candidates))
+	for i, c := range candidates {
+		inferred[i] = Format(c.format.String())
+	}
+
+	return inferred, nil
+}
+
+// plausible tells if the format could have been picked for the versions. A
+// short year is ruled out for four digit values, since those could only be
+// years a few thousand years from now
+func (f *format) plausible(cores []string) bool {
+	for i, p := range f.parts() {
+		if len(p) == 0 || (p[0] != segmentShortYear && p[0] != segmentPaddedYear) {
+			continue
+		}
+
+		for _, c := range cores {
+			v := strings.Split(c, ".")[i]
+			if n, _ := strconv.Atoi(v); n >= 100 && len(v) >= 4 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// distinct tells if every segment of the format stands for a different length
+// of time, so that there aren't two years or a month and a week in it
+func (f *format) distinct() bool {
+	seen := map[int]bool{}
+	for _, p := range f.parts() {
+		for _, s := range p {
+			if seen[s.granularity()] {
+				return false
+			}
+			seen[s.granularity()] = true
+		}
+	}
+
+	return true
+}
+
+// formats returns every format that could be built out of the given number of
+// single segment parts. Segments for the time of the day and micro are left
+// out as they would fit pretty much any small number, and so are formats with
+// segments for the same length of time
+func formats(parts int) []*format {
+	var dates []string
+	for _, v := range valid {
+		if s, _ := newSegment(v); !s.clock() && s != segmentMicro {
+			dates = append(dates, v)
+		}
+	}
+
+	var all []*format
+	for _, major := range dates {
+		for _, minor := range dates {
+			if parts == 2 {
+				if f, _ := newFormat(fmt.Sprintf("%s.%s", major, minor)); f.distinct() {
+					all = append(all, f)
+				}
+				continue
+			}
+
+			for _, micro := range dates {
+				if f, _ := newFormat(fmt.Sprintf("%s.%s.%s", major, minor, micro)); f.distinct() {
+					all = append(all, f)
+				}
+			}
+		}
+	}
+
+	return all
+}
+
+// score tells how plausible the format is for the provided versions, the
+// higher the better
+func (f *format) score(cores []string) float64 {
+	segs := make([]segment, 0, 3)
+	for _, p := range f.parts() {
+		if len(p) > 0 {
+			segs = append(segs, p[0])
+		}
+	}
+
+	score := 0.0
+	for i, s := range segs {
+		highest := 0
+		for _, c := range cores {
+			v, _ := strconv.Atoi(strings.Split(c, ".")[i])
+			if v > highest {
+				highest = v
+			}
+		}
+
+		switch {
+		case s == segmentFullYear:
+			score++
+		case highest > s.limit():
+			// a short year way past a hundred is possible but unlikely
+		case s.granularity() == 1:
+			score++
+		default:
+			score += float64(highest) / float64(s.limit())
+		}
+
+		if i > 0 && s.granularity() <= segs[i-1].granularity() {
+			score -= float64(segs[i-1].granularity() - s.granularity() + 1)
+		}
+	}
+
+	return score
+}
@@ -0,0 +1,73 @@
+package calver
+
+import (
+	"testing"
+)
+
+func TestInfer(t *testing.T) {
+	cases := []struct {
+		samples  []string
+		expected Format
+	}{
+		{[]string{"2019.01.05", "2020.12.20-1", "2021.03.31-dev.2"}, "YYYY.0M.0D"},
+		{[]string{"2019.1.5", "2020.12.20", "2021.3.31"}, "YYYY.MM.DD"},
+		{[]string{"19.1", "20.12", "21.3"}, "YY.MM"},
+		{[]string{"19.01", "20.12", "21.03"}, "YY.0M"},
+		{[]string{"07.02", "08.11"}, "0Y.0M"},
+		{[]string{"2019.13", "2020.2"}, "YYYY.DD"},
+		{[]string{"2019.53", "2020.2"}, "YYYY.WW"},
+		{[]string{"2019.1", "2020.2.1"}, ""},
+	}
+
+	for _, tc := range cases {
+		formats, err := Infer(tc.samples)
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("format should not be inferred from %v", tc.samples)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("failed to infer the format from %v: %s", tc.samples, err)
+		}
+
+		if formats[0] != tc.expected {
+			t.Errorf("inferred format from %v should be %s but it was %v", tc.samples, tc.expected, formats)
+		}
+
+		for _, f := range formats {
+			for _, s := range tc.samples {
+				if _, err := Parse(s, string(f), ""); err != nil {
+					t.Errorf("inferred format %s should parse %s: %s", f, s, err)
+				}
+			}
+		}
+	}
+
+	if _, err := Infer(nil); err == nil {
+		t.Error("format should not be inferred without any samples")
+	}
+}
+
+func TestInfer_Plausible(t *testing.T) {
+	formats, err := Infer([]string{"2019.01.05", "2020.12.20-1"})
+	if err != nil {
+		t.Fatalf("failed to infer the format: %s", err)
+	}
+
+	for _, f := range formats {
+		parsed, _ := newFormat(string(f))
+		if !parsed.distinct() {
+			t.Errorf("inferred format %s should not have segments for the same length of time", f)
+		}
+
+		if parsed.has(segmentShortYear, segmentPaddedYear) {
+			t.Errorf("inferred format %s should not have a short year for four digit values", f)
+		}
+	}
+
+	if len(formats) > 3 {
+		t.Errorf("inferred formats should be a short list but it was %v", formats)
+	}
+}
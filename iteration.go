@@ -0,0 +1,81 @@
+package calver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Iteration tells how the iteration is rendered after the version. The zero
+// value renders `2020.12.20-2` for releases and `2020.12.20-dev.2` for
+// prereleases, leaving out the iteration when it's 0
+type Iteration struct {
+	// Separator goes between the version and the iteration of a release, it
+	// could be one of `-`, `.`, `_` and `+` and defaults to `-`. The
+	// iteration of a prerelease always goes after the modifier and a `.`
+	Separator string
+	// Width pads the iteration with zeros up to the number of digits, for
+	// instance `2020.12.20-002` with a width of 3, so that versions sort
+	// lexically
+	Width int
+	// Zero renders the iteration of prereleases even when it's 0, that is
+	// `2020.12.20-dev.0` rather than `2020.12.20-dev`
+	Zero bool
+}
+
+// WithIteration is an option to render and parse the iteration in a
+// different way
+func WithIteration(it Iteration) Option {
+	return func(c *CalVer) error {
+		switch it.Separator {
+		case "", "-", ".", "_", "+":
+		default:
+			return fmt.Errorf("invalid separator for the iteration: %s", it.Separator)
+		}
+
+		if it.Width < 0 {
+			return fmt.Errorf("invalid width for the iteration: %d", it.Width)
+		}
+
+		c.iter = it
+		return nil
+	}
+}
+
+func (it Iteration) separator() string {
+	if it.Separator == "" {
+		return "-"
+	}
+
+	return it.Separator
+}
+
+// render returns the iteration along with what goes before it
+func (it Iteration) render(inc uint64, pre bool) string {
+	sep := it.separator()
+	if pre {
+		sep = "."
+	}
+
+	return fmt.Sprintf("%s%0*d", sep, it.Width, inc)
+}
+
+// split separates the version of a release from its iteration, if any, given
+// how many parts the version has. It also tells if there was a separator, so
+// that one without an iteration after it could be told apart from none
+func (it Iteration) split(raw string, parts int) (string, string, bool) {
+	if it.separator() == "." {
+		segs := strings.Split(raw, ".")
+		if len(segs) <= parts {
+			return raw, "", false
+		}
+
+		return strings.Join(segs[:parts], "."), strings.Join(segs[parts:], "."), true
+	}
+
+	i := strings.LastIndex(raw, it.separator())
+	if i < 0 {
+		return raw, "", false
+	}
+
+	return raw[:i], raw[i+1:], true
+}
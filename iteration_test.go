@@ -0,0 +1,97 @@
+package calver
+
+import (
+	"testing"
+)
+
+func TestNew_Iteration(t *testing.T) {
+	c, err := New("YYYY.MM.DD", "dev", WithIteration(Iteration{Separator: "+", Width: 3, Zero: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		pre bool
+		v   string
+	}{
+		{true, "2007.2.5-dev.000"},
+		{false, "2007.2.5"},
+		{true, "2007.2.5-dev.001"},
+		{false, "2007.2.5+001"},
+		{false, "2007.2.5+002"},
+	}
+
+	for _, e := range expected {
+		var actual string
+		if e.pre {
+			actual = c.PreRelease()
+		} else {
+			actual = c.Release()
+		}
+
+		if actual != e.v {
+			t.Errorf("release version should be %s but it was %s", e.v, actual)
+		}
+	}
+}
+
+func TestNew_IterationInvalid(t *testing.T) {
+	if _, err := New("YYYY.MM.DD", "dev", WithIteration(Iteration{Separator: "~"})); err == nil {
+		t.Error("iteration with an invalid separator should not be accepted")
+	}
+
+	if _, err := New("YYYY.MM.DD", "dev", WithIteration(Iteration{Width: -1})); err == nil {
+		t.Error("iteration with a negative width should not be accepted")
+	}
+}
+
+func TestParse_Iteration(t *testing.T) {
+	for _, tc := range []struct {
+		it  Iteration
+		raw string
+		inc uint64
+		pre bool
+	}{
+		{Iteration{}, "2007.2.5-10", 10, false},
+		{Iteration{Width: 3}, "2007.2.5-010", 10, false},
+		{Iteration{Separator: "."}, "2007.2.5.3", 3, false},
+		{Iteration{Separator: "."}, "2007.2.5", 0, false},
+		{Iteration{Separator: "_"}, "2007.2.5_4", 4, false},
+		{Iteration{Separator: "+"}, "2007.2.5-dev.4", 4, true},
+		{Iteration{Zero: true}, "2007.2.5-dev.0", 0, true},
+	} {
+		c, err := Parse(tc.raw, "YYYY.MM.DD", "dev", WithIteration(tc.it))
+		if err != nil {
+			t.Errorf("failed to parse %s: %s", tc.raw, err)
+			continue
+		}
+
+		if c.increment != tc.inc || c.pre != tc.pre {
+			t.Errorf("iteration of %s should be %d (pre: %t) but it was %d (pre: %t)", tc.raw, tc.inc, tc.pre, c.increment, c.pre)
+		}
+
+		if c.String() != tc.raw {
+			t.Errorf("parsed version should be %s but it was %s", tc.raw, c.String())
+		}
+	}
+
+	for _, raw := range []string{"2007.2.5-3", "2007.2.5.x", "2007.2.5-dev3", "2007.2.5.", "2007.2.5-dev."} {
+		if _, err := Parse(raw, "YYYY.MM.DD", "dev", WithIteration(Iteration{Separator: "."})); err == nil {
+			t.Errorf("invalid version %s should not be parsed", raw)
+		}
+	}
+
+	for _, raw := range []string{"2021.3.5-", "2021.3.5-dev."} {
+		if _, err := Parse(raw, "YYYY.MM.DD", "dev"); err == nil {
+			t.Errorf("invalid version %s should not be parsed", raw)
+		}
+	}
+}
+
+func TestIteration_Lexical(t *testing.T) {
+	a, _ := Parse("2021.3.5-9", "YYYY.MM.DD", "dev", WithIteration(Iteration{Width: 3}))
+	b, _ := Parse("2021.3.5-10", "YYYY.MM.DD", "dev", WithIteration(Iteration{Width: 3}))
+	if !(a.String() < b.String()) {
+		t.Errorf("version %s should sort before %s", a, b)
+	}
+}
@@ -0,0 +1,88 @@
+package calver
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Monotonic tells what to do when the current time would make a version that
+// comes before the previous one, for instance because of a clock that's off,
+// a wrong timezone or a version from the future. It only applies to formats
+// that could be told a date, the others wrap around and always allow it
+type Monotonic int
+
+const (
+	// MonotonicRefuse leaves the version as it is and reports an error
+	// through Err, it's the default
+	MonotonicRefuse Monotonic = iota
+	// MonotonicKeep keeps the date of the previous version and bumps the
+	// iteration instead, as if it was released at the same time
+	MonotonicKeep
+	// MonotonicAllow lets the version go backwards
+	MonotonicAllow
+)
+
+var monotonics = map[string]Monotonic{
+	"refuse": MonotonicRefuse,
+	"keep":   MonotonicKeep,
+	"allow":  MonotonicAllow,
+}
+
+// ParseMonotonic returns the policy for the provided name, which is one of
+// `refuse`, `keep` or `allow`
+func ParseMonotonic(name string) (Monotonic, error) {
+	m, ok := monotonics[name]
+	if !ok {
+		return 0, fmt.Errorf("invalid monotonic policy: %s", name)
+	}
+
+	return m, nil
+}
+
+func (m Monotonic) String() string {
+	for name, v := range monotonics {
+		if v == m {
+			return name
+		}
+	}
+
+	return strconv.Itoa(int(m))
+}
+
+// WithMonotonic is an option to choose what happens when the next version
+// would come before the previous one
+func WithMonotonic(m Monotonic) Option {
+	return func(c *CalVer) error {
+		if m < MonotonicRefuse || m > MonotonicAllow {
+			return fmt.Errorf("invalid monotonic policy: %d", m)
+		}
+
+		c.monotonic = m
+		return nil
+	}
+}
+
+// Err returns the error from the last Release or PreRelease, if any. It's
+// set when the version would have gone backwards with MonotonicRefuse, when
+// the format has a short year and the time is before 2000, or when the time
+// is out of the range of a custom segment, in which cases the version is left
+// as it was
+func (c *CalVer) Err() error {
+	return c.err
+}
+
+// behind tells if the time comes before the period of the version, which is
+// how a clock going backwards shows. It's told by dates rather than by the
+// segments so that `YYYY.WW` going from week 53 to 1 in January isn't taken
+// for it, and versions that can't be told a date, like `MM.DD`, are never
+// behind since they wrap around
+func (c *CalVer) behind(t time.Time) bool {
+	dates, _, err := c.dates()
+	if err != nil {
+		return false
+	}
+
+	// the dates are in UTC for the time of the clock the version was made at
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC).Before(dates[0])
+}
@@ -0,0 +1,142 @@
+package calver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelease_Monotonic(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	c, _ := Parse("2021.3.5-2", "YYYY.MM.DD", "dev")
+	if r := c.Release(); r != "2021.3.5-2" {
+		t.Errorf("release version should be 2021.3.5-2 but it was %s", r)
+	}
+
+	if c.Err() == nil {
+		t.Error("version going backwards should be reported")
+	}
+
+	c, _ = Parse("2021.3.5-2", "YYYY.MM.DD", "dev", WithMonotonic(MonotonicKeep))
+	if r := c.Release(); r != "2021.3.5-3" || c.Err() != nil {
+		t.Errorf("release version should be 2021.3.5-3 but it was %s (%v)", r, c.Err())
+	}
+
+	if r := c.PreRelease(); r != "2021.3.5-dev.4" {
+		t.Errorf("pre-release version should be 2021.3.5-dev.4 but it was %s", r)
+	}
+
+	c, _ = Parse("2021.3.5-2", "YYYY.MM.DD", "dev", WithMonotonic(MonotonicAllow))
+	if r := c.Release(); r != "2021.3.4" || c.Err() != nil {
+		t.Errorf("release version should be 2021.3.4 but it was %s (%v)", r, c.Err())
+	}
+
+	// the error is cleared once the clock catches up
+	c, _ = Parse("2021.3.5", "YYYY.MM.DD", "dev")
+	c.Release()
+
+	reset2 := mockNowFunc(func() time.Time {
+		return time.Date(2021, 3, 6, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset2()
+
+	if r := c.Release(); r != "2021.3.6" || c.Err() != nil {
+		t.Errorf("release version should be 2021.3.6 but it was %s (%v)", r, c.Err())
+	}
+}
+
+func TestNext(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	c, _ := Parse("2021.3.5-2", "YYYY.MM.DD", "dev")
+	if r, err := c.Next(false); err == nil {
+		t.Errorf("next version going backwards should be reported but it was %s", r)
+	}
+
+	if c.String() != "2021.3.5-2" {
+		t.Errorf("version should be left as 2021.3.5-2 but it was %s", c)
+	}
+
+	c, _ = Parse("2021.3.3", "YYYY.MM.DD", "dev")
+	if r, err := c.Next(true); r != "2021.3.4-dev" || err != nil {
+		t.Errorf("next version should be 2021.3.4-dev but it was %s (%v)", r, err)
+	}
+
+	if r, err := c.Next(false); r != "2021.3.4" || err != nil {
+		t.Errorf("next version should be 2021.3.4 but it was %s (%v)", r, err)
+	}
+}
+
+func TestRelease_MonotonicOrder(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	// 10 comes after 9 even though it doesn't as a string
+	c, _ := Parse("2021.9.30", "YYYY.MM.DD", "dev")
+	if r := c.Release(); r != "2021.10.1" || c.Err() != nil {
+		t.Errorf("release version should be 2021.10.1 but it was %s (%v)", r, c.Err())
+	}
+}
+
+func TestRelease_MonotonicWrap(t *testing.T) {
+	for _, tc := range []struct {
+		format   string
+		previous string
+		now      time.Time
+		expected string
+		refused  bool
+	}{
+		{"MM.DD", "12.31", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), "1.1", false},
+		{"0H.0m.0S", "23.59.59", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), "00.00.00", false},
+		{"YYYY.WW", "2021.53", time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC), "2021.1", false},
+		{"YYYY.WW", "2024.52", time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), "2024.1", false},
+		{"YYYY.0W", "2021.20", time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), "2021.20", true},
+		{"YYYY.0W", "2021.09", time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC), "2021.10", false},
+		{"YYYY.Q.DD", "2021.1.31", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), "2021.1.1", false},
+		{"YYYY.Q.DD", "2021.1.31", time.Date(2021, 1, 30, 0, 0, 0, 0, time.UTC), "2021.1.31", true},
+	} {
+		reset := mockNowFunc(func() time.Time {
+			return tc.now
+		})
+
+		c, err := Parse(tc.previous, tc.format, "dev")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", tc.previous, err)
+		}
+
+		if r := c.Release(); r != tc.expected || (c.Err() != nil) != tc.refused {
+			t.Errorf("release version of %s after %s should be %s (refused: %t) but it was %s (%v)", tc.format, tc.previous, tc.expected, tc.refused, r, c.Err())
+		}
+
+		reset()
+	}
+}
+
+func TestParseMonotonic(t *testing.T) {
+	for name, expected := range map[string]Monotonic{
+		"refuse": MonotonicRefuse,
+		"keep":   MonotonicKeep,
+		"allow":  MonotonicAllow,
+	} {
+		m, err := ParseMonotonic(name)
+		if err != nil || m != expected || m.String() != name {
+			t.Errorf("monotonic policy should be %s but it was %s (%v)", name, m, err)
+		}
+	}
+
+	if _, err := ParseMonotonic("never"); err == nil {
+		t.Error("invalid monotonic policy should not be parsed")
+	}
+
+	if _, err := New("YYYY.MM.DD", "dev", WithMonotonic(Monotonic(5))); err == nil {
+		t.Error("invalid monotonic policy should not be accepted")
+	}
+}
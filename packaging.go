@@ -0,0 +1,205 @@
+package calver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Native package managers don't understand `2020.12.20-dev.2` the way CalVer
+// does, so the conversions below map a version into a form whose native
+// ordering matches the CalVer ordering, that is:
+//		2020.12.20-dev < 2020.12.20 < 2020.12.20-dev.1 < 2020.12.20-1
+// A prerelease with an iteration comes right before the release with the same
+// iteration, since that's what Release produces out of it.
+
+// fill sets the version segments from plain numbers, re-padding them where the
+// format asks for it. It's used when parsing from the package manager forms
+// which don't necessarily keep the leading zeros
+func (c *CalVer) fill(raw string) error {
+	nums := strings.Split(raw, ".")
+	segs := c.format.parts()
+
+	parts := make([]string, 0, len(nums))
+	for i, n := range nums {
+		if i >= len(segs) || len(segs[i]) == 0 {
+			return fmt.Errorf("provided string doesn't match the format: %s", c.format)
+		}
+
+		v, err := strconv.Atoi(n)
+		if err != nil || v < 0 {
+			return fmt.Errorf("provided string doesn't match the format: %s", c.format)
+		}
+
+		parts = append(parts, segs[i].itoa(v))
+	}
+
+	return c.set(strings.Join(parts, "."))
+}
+
+// PEP440 returns the version in the form Python packaging expects, for
+// instance:
+//		2020.12.20			->	2020.12.20
+//		2020.12.20-2		->	2020.12.20.post2
+//		2020.12.20-dev		->	2020.12.20.dev0
+//		2020.12.20-dev.2	->	2020.12.20.post2.dev0
+// Leading zeros are dropped as PEP 440 normalizes them anyway
+func (c *CalVer) PEP440() string {
+	nums := strings.Split(c.core(), ".")
+	for i, n := range nums {
+		if v, err := strconv.Atoi(n); err == nil {
+			nums[i] = strconv.Itoa(v)
+		}
+	}
+
+	v := strings.Join(nums, ".")
+
+	if c.iteration() > 0 {
+		v += fmt.Sprintf(".post%d", c.iteration())
+	}
+
+	if c.pre {
+		v += ".dev0"
+	}
+
+	return v
+}
+
+// ParsePEP440 takes a version produced by PEP440 and returns the CalVer
+// instance for the provided format and modifier
+func ParsePEP440(raw, format, modifier string, opts ...Option) (*CalVer, error) {
+	c, err := New(format, modifier, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	v := raw
+	if strings.HasSuffix(v, ".dev0") {
+		c.pre = true
+		v = strings.TrimSuffix(v, ".dev0")
+	}
+
+	if i := strings.Index(v, ".post"); i >= 0 {
+		inc, err := strconv.ParseUint(v[i+len(".post"):], 10, 64)
+		if err != nil || inc == 0 {
+			return nil, fmt.Errorf("provided string isn't a valid PEP 440 version: %s", raw)
+		}
+
+		c.increment = inc
+		v = v[:i]
+	}
+
+	if err := c.fill(v); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Debian returns the version in the form dpkg expects, using the revision
+// for the iteration and a tilde for the prerelease, for instance:
+//		2020.12.20			->	2020.12.20
+//		2020.12.20-2		->	2020.12.20-2
+//		2020.12.20-dev		->	2020.12.20~dev
+//		2020.12.20-dev.2	->	2020.12.20-2~dev
+func (c *CalVer) Debian() string {
+	v := c.core()
+
+	if c.iteration() > 0 {
+		v += fmt.Sprintf("-%d", c.iteration())
+	}
+
+	if c.pre {
+		v += fmt.Sprintf("~%s", c.modifier)
+	}
+
+	return v
+}
+
+// ParseDebian takes a version produced by Debian and returns the CalVer
+// instance for the provided format and modifier
+func ParseDebian(raw, format, modifier string, opts ...Option) (*CalVer, error) {
+	c, err := New(format, modifier, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	v := raw
+	if strings.HasSuffix(v, fmt.Sprintf("~%s", c.modifier)) {
+		c.pre = true
+		v = strings.TrimSuffix(v, fmt.Sprintf("~%s", c.modifier))
+	}
+
+	if i := strings.LastIndex(v, "-"); i >= 0 {
+		inc, err := strconv.ParseUint(v[i+1:], 10, 64)
+		if err != nil || inc == 0 {
+			return nil, fmt.Errorf("provided string isn't a valid Debian version: %s", raw)
+		}
+
+		c.increment = inc
+		v = v[:i]
+	}
+
+	if err := c.fill(v); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// RPM returns the version split into the `Version` and `Release` fields of a
+// spec file. The iteration goes into the release and the prerelease is
+// marked with a tilde, for instance:
+//		2020.12.20			->	2020.12.20		0
+//		2020.12.20-2		->	2020.12.20		2
+//		2020.12.20-dev		->	2020.12.20~dev	0
+//		2020.12.20-dev.2	->	2020.12.20		2~dev
+func (c *CalVer) RPM() (string, string) {
+	version, release := c.core(), strconv.FormatUint(c.iteration(), 10)
+
+	if c.pre {
+		if c.iteration() == 0 {
+			version += fmt.Sprintf("~%s", c.modifier)
+		} else {
+			release += fmt.Sprintf("~%s", c.modifier)
+		}
+	}
+
+	return version, release
+}
+
+// ParseRPM takes the `Version` and `Release` fields produced by RPM and
+// returns the CalVer instance for the provided format and modifier
+func ParseRPM(version, release, format, modifier string, opts ...Option) (*CalVer, error) {
+	c, err := New(format, modifier, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tilde := fmt.Sprintf("~%s", c.modifier)
+
+	preVersion := strings.HasSuffix(version, tilde)
+	preRelease := strings.HasSuffix(release, tilde)
+
+	errBadRelease := fmt.Errorf("provided string isn't a valid RPM release: %s", release)
+	if preVersion && preRelease {
+		return nil, errBadRelease
+	}
+
+	c.pre = preVersion || preRelease
+	version = strings.TrimSuffix(version, tilde)
+	release = strings.TrimSuffix(release, tilde)
+
+	inc, err := strconv.ParseUint(release, 10, 64)
+	if err != nil || (preVersion && inc > 0) || (preRelease && inc == 0) {
+		return nil, errBadRelease
+	}
+
+	c.increment = inc
+
+	if err := c.fill(version); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,112 @@
+package calver
+
+import (
+	"testing"
+)
+
+func TestCalVer_PEP440(t *testing.T) {
+	cases := map[string]string{
+		"2007.02.05":        "2007.2.5",
+		"2007.02.05-2":      "2007.2.5.post2",
+		"2007.02.05-dev":    "2007.2.5.dev0",
+		"2007.02.05-dev.2":  "2007.2.5.post2.dev0",
+		"2007.02.05-dev.10": "2007.2.5.post10.dev0",
+	}
+
+	for raw, expected := range cases {
+		c, err := Parse(raw, "YYYY.0M.0D", "")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", raw, err)
+		}
+
+		if c.PEP440() != expected {
+			t.Errorf("PEP 440 version of %s should be %s but it was %s", raw, expected, c.PEP440())
+		}
+
+		p, err := ParsePEP440(expected, "YYYY.0M.0D", "")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", expected, err)
+		}
+
+		if p.String() != raw {
+			t.Errorf("parsed PEP 440 version %s should be %s but it was %s", expected, raw, p.String())
+		}
+	}
+
+	for _, raw := range []string{"2007.2", "2007.2.5.post0", "2007.2.5.postx", "2007.13.5.dev0"} {
+		if _, err := ParsePEP440(raw, "YYYY.0M.0D", ""); err == nil {
+			t.Errorf("invalid PEP 440 version %s should not be parsed", raw)
+		}
+	}
+}
+
+func TestCalVer_Debian(t *testing.T) {
+	cases := map[string]string{
+		"2007.2.5":        "2007.2.5",
+		"2007.2.5-2":      "2007.2.5-2",
+		"2007.2.5-beta":   "2007.2.5~beta",
+		"2007.2.5-beta.2": "2007.2.5-2~beta",
+	}
+
+	for raw, expected := range cases {
+		c, err := Parse(raw, "YYYY.MM.DD", "beta")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", raw, err)
+		}
+
+		if c.Debian() != expected {
+			t.Errorf("Debian version of %s should be %s but it was %s", raw, expected, c.Debian())
+		}
+
+		p, err := ParseDebian(expected, "YYYY.MM.DD", "beta")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", expected, err)
+		}
+
+		if p.String() != raw {
+			t.Errorf("parsed Debian version %s should be %s but it was %s", expected, raw, p.String())
+		}
+	}
+
+	for _, raw := range []string{"2007.2.5-0", "2007.2.5-x", "2007.2.5~dev"} {
+		if _, err := ParseDebian(raw, "YYYY.MM.DD", "beta"); err == nil {
+			t.Errorf("invalid Debian version %s should not be parsed", raw)
+		}
+	}
+}
+
+func TestCalVer_RPM(t *testing.T) {
+	cases := map[string][2]string{
+		"7.02":       {"7.02", "0"},
+		"7.02-2":     {"7.02", "2"},
+		"7.02-dev":   {"7.02~dev", "0"},
+		"7.02-dev.2": {"7.02", "2~dev"},
+	}
+
+	for raw, expected := range cases {
+		c, err := Parse(raw, "YY.0M", "")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", raw, err)
+		}
+
+		version, release := c.RPM()
+		if version != expected[0] || release != expected[1] {
+			t.Errorf("RPM version of %s should be %v but it was [%s %s]", raw, expected, version, release)
+		}
+
+		p, err := ParseRPM(expected[0], expected[1], "YY.0M", "")
+		if err != nil {
+			t.Fatalf("failed to parse %v: %s", expected, err)
+		}
+
+		if p.String() != raw {
+			t.Errorf("parsed RPM version %v should be %s but it was %s", expected, raw, p.String())
+		}
+	}
+
+	for _, v := range [][2]string{{"7.02~dev", "2"}, {"7.02", "0~dev"}, {"7.02~dev", "1~dev"}, {"7.02", ""}} {
+		if _, err := ParseRPM(v[0], v[1], "YY.0M", ""); err == nil {
+			t.Errorf("invalid RPM version %v should not be parsed", v)
+		}
+	}
+}
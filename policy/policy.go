@@ -0,0 +1,218 @@
+// Package policy tells which CalVer releases are supported at a point in
+// time, out of a list of releases and a few declarative rules, for instance:
+//		p, _ := policy.New(releases,
+//			policy.Rule{Latest: 3},
+//			policy.Rule{Match: policy.InMonths(time.April, time.October), Years: 2},
+//		)
+//		p.Supported(v, time.Now())
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/umayr/calver"
+)
+
+// Rule tells which releases are supported and for how long. A release is
+// supported as long as any of the rules supports it
+type Rule struct {
+	// Match picks the releases the rule is about, all of them when it's nil
+	Match func(v *calver.CalVer) bool
+	// Latest supports only the latest releases the rule matches, from
+	// different periods, until there are that many newer ones. Zero doesn't
+	// limit the releases by their number
+	Latest int
+	// Years, Months and Days tell how long a release is supported for from
+	// the start of its period. Zero for all of them doesn't limit the
+	// releases by their age
+	Years, Months, Days int
+}
+
+// InMonths matches the releases whose period starts in one of the months,
+// like the `YY.04` and `YY.10` releases of Ubuntu
+func InMonths(months ...time.Month) func(v *calver.CalVer) bool {
+	return func(v *calver.CalVer) bool {
+		start, _, err := v.Period()
+		if err != nil {
+			return false
+		}
+
+		for _, m := range months {
+			if start.Month() == m {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// release is a release along with when its period starts
+type release struct {
+	version *calver.CalVer
+	start   time.Time
+}
+
+// Policy answers which releases are supported by its rules
+type Policy struct {
+	releases []release
+	rules    []Rule
+}
+
+// New returns a policy for the provided releases and rules. Prereleases are
+// left out since they are never supported, and all the releases need to be
+// told a date, so their format needs a year segment
+func New(releases []*calver.CalVer, rules ...Rule) (*Policy, error) {
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("at least one rule is required for a policy")
+	}
+
+	p := &Policy{rules: rules}
+	for _, r := range rules {
+		if r.Latest < 0 || r.Years < 0 || r.Months < 0 || r.Days < 0 {
+			return nil, fmt.Errorf("invalid rule for a policy: %+v", r)
+		}
+	}
+
+	for _, v := range releases {
+		if v.IsPreRelease() {
+			continue
+		}
+
+		start, _, err := v.Period()
+		if err != nil {
+			return nil, err
+		}
+
+		p.releases = append(p.releases, release{v, start})
+	}
+
+	sort.SliceStable(p.releases, func(i, j int) bool {
+		return calver.Compare(p.releases[i].version, p.releases[j].version) < 0
+	})
+
+	return p, nil
+}
+
+// start returns when the period of the version starts
+func start(v *calver.CalVer) (time.Time, bool) {
+	t, _, err := v.Period()
+	return t, err == nil
+}
+
+// newer returns the starts of the periods of the releases matching the rule
+// that come after the provided time, oldest first
+func (p *Policy) newer(r Rule, from time.Time) []time.Time {
+	var starts []time.Time
+	for _, rel := range p.releases {
+		if !rel.start.After(from) || (r.Match != nil && !r.Match(rel.version)) {
+			continue
+		}
+
+		if n := len(starts); n == 0 || !starts[n-1].Equal(rel.start) {
+			starts = append(starts, rel.start)
+		}
+	}
+
+	return starts
+}
+
+// until returns when the rule stops supporting a release from the provided
+// time, and false if that can't be told yet or never happens. It's assumed
+// the rule matches the release
+func (p *Policy) until(r Rule, from time.Time) (time.Time, bool) {
+	var (
+		end   time.Time
+		known bool
+	)
+
+	if r.Years > 0 || r.Months > 0 || r.Days > 0 {
+		end, known = from.AddDate(r.Years, r.Months, r.Days), true
+	}
+
+	if r.Latest > 0 {
+		// it's superseded once there are that many newer periods released
+		if newer := p.newer(r, from); len(newer) >= r.Latest {
+			if t := newer[r.Latest-1]; !known || t.Before(end) {
+				end, known = t, true
+			}
+		}
+	}
+
+	return end, known
+}
+
+// Supported tells if the version is supported at the provided time, which
+// requires it to be released by then, that is its period to have started
+func (p *Policy) Supported(v *calver.CalVer, at time.Time) bool {
+	from, ok := start(v)
+	if !ok || v.IsPreRelease() || at.Before(from) {
+		return false
+	}
+
+	for _, r := range p.rules {
+		if r.Match != nil && !r.Match(v) {
+			continue
+		}
+
+		end, known := p.until(r, from)
+		if !known || at.Before(end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EOL returns when the version stops being supported, which is the latest
+// end of all the rules matching it. It returns false when that can't be told
+// yet, since there aren't enough newer releases, or when it's supported
+// forever by a rule without limits
+func (p *Policy) EOL(v *calver.CalVer) (time.Time, bool) {
+	from, ok := start(v)
+	if !ok || v.IsPreRelease() {
+		return time.Time{}, false
+	}
+
+	var (
+		eol     time.Time
+		matched bool
+	)
+	for _, r := range p.rules {
+		if r.Match != nil && !r.Match(v) {
+			continue
+		}
+
+		end, known := p.until(r, from)
+		if !known {
+			return time.Time{}, false
+		}
+
+		if !matched || end.After(eol) {
+			eol = end
+		}
+		matched = true
+	}
+
+	// not matching any rule means it's never supported
+	if !matched {
+		return from, true
+	}
+
+	return eol, true
+}
+
+// SupportedSet returns all the releases that are supported at the provided
+// time, oldest first
+func (p *Policy) SupportedSet(at time.Time) []*calver.CalVer {
+	var supported []*calver.CalVer
+	for _, rel := range p.releases {
+		if p.Supported(rel.version, at) {
+			supported = append(supported, rel.version)
+		}
+	}
+
+	return supported
+}
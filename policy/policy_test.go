@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/umayr/calver"
+)
+
+func parse(t *testing.T, format string, raws ...string) []*calver.CalVer {
+	t.Helper()
+
+	var versions []*calver.CalVer
+	for _, raw := range raws {
+		v, err := calver.Parse(raw, format, "dev")
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", raw, err)
+		}
+
+		versions = append(versions, v)
+	}
+
+	return versions
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func strings(versions []*calver.CalVer) []string {
+	var s []string
+	for _, v := range versions {
+		s = append(s, v.String())
+	}
+
+	return s
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestPolicy_Latest(t *testing.T) {
+	releases := parse(t, "YYYY.0M", "2021.05", "2021.01", "2021.02", "2021.02-1", "2021.03", "2021.04-dev", "2021.04")
+
+	p, err := New(releases, Rule{Latest: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := strings(p.SupportedSet(date(2021, 4, 15))); !equal(s, []string{"2021.02", "2021.02-1", "2021.03", "2021.04"}) {
+		t.Errorf("supported set should be [2021.02 2021.02-1 2021.03 2021.04] but it was %v", s)
+	}
+
+	if s := strings(p.SupportedSet(date(2021, 5, 15))); !equal(s, []string{"2021.03", "2021.04", "2021.05"}) {
+		t.Errorf("supported set should be [2021.03 2021.04 2021.05] but it was %v", s)
+	}
+
+	eol, ok := p.EOL(releases[2])
+	if !ok || !eol.Equal(date(2021, 5, 1)) {
+		t.Errorf("end of 2021.02 should be 1st of May 2021 but it was %s (%t)", eol, ok)
+	}
+
+	if _, ok := p.EOL(releases[4]); ok {
+		t.Error("end of 2021.03 should not be told yet")
+	}
+
+	if p.Supported(releases[5], date(2021, 4, 15)) {
+		t.Error("prereleases should not be supported")
+	}
+
+	if p.Supported(releases[0], date(2021, 4, 15)) {
+		t.Error("release from the future should not be supported")
+	}
+}
+
+func TestPolicy_LTS(t *testing.T) {
+	releases := parse(t, "YY.0M", "20.04", "20.07", "20.10", "21.01", "21.04", "21.07", "21.10", "22.04")
+
+	p, err := New(releases,
+		Rule{Latest: 1},
+		Rule{Match: InMonths(time.April, time.October), Years: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"20.04", "20.10", "21.04", "21.10"}
+	if s := strings(p.SupportedSet(date(2022, 3, 15))); !equal(s, expected) {
+		t.Errorf("supported set should be %v but it was %v", expected, s)
+	}
+
+	if eol, ok := p.EOL(releases[0]); !ok || !eol.Equal(date(2022, 4, 1)) {
+		t.Errorf("end of 20.04 should be 1st of April 2022 but it was %s (%t)", eol, ok)
+	}
+
+	if eol, ok := p.EOL(releases[1]); !ok || !eol.Equal(date(2020, 10, 1)) {
+		t.Errorf("end of 20.07 should be 1st of October 2020 but it was %s (%t)", eol, ok)
+	}
+
+	if p.Supported(releases[0], date(2022, 4, 1)) {
+		t.Error("20.04 should not be supported after two years")
+	}
+}
+
+func TestPolicy_Forever(t *testing.T) {
+	releases := parse(t, "YYYY.MM.DD", "2021.3.5")
+
+	p, _ := New(releases, Rule{})
+	if _, ok := p.EOL(releases[0]); ok {
+		t.Error("end of a rule without limits should not be told")
+	}
+
+	if !p.Supported(releases[0], date(2100, 1, 1)) {
+		t.Error("release should be supported forever")
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Error("policy without any rules should not be accepted")
+	}
+
+	if _, err := New(nil, Rule{Latest: -1}); err == nil {
+		t.Error("negative rule should not be accepted")
+	}
+
+	if _, err := New(parse(t, "MM.DD", "3.5"), Rule{}); err == nil {
+		t.Error("release without a year should not be accepted")
+	}
+}
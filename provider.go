@@ -0,0 +1,114 @@
+package calver
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Context holds what a custom segment could render its value out of
+type Context struct {
+	// Time is when the version is being generated
+	Time time.Time
+	// Calendar is the calendar the format follows
+	Calendar Calendar
+}
+
+// SegmentProvider is a custom segment that could be used in formats along
+// with the built-in ones, for instance a build number from CI or a release
+// train codename
+type SegmentProvider interface {
+	// Name is the token for the segment in formats, for instance `BUILD`
+	Name() string
+	// Render returns the value of the segment for a new version
+	Render(ctx Context) string
+	// Parse validates a value of the segment and returns it the way Render
+	// would have
+	Parse(raw string) (string, error)
+	// Compare returns -1, 0 or 1 if the first value comes before, is the same
+	// as or comes after the second one
+	Compare(a, b string) int
+}
+
+// SegmentRange could be implemented by custom segments that stand for a
+// period of time, so that versions using them could be told a date
+type SegmentRange interface {
+	// Range returns when the period for the value of the segment starts and
+	// when the next one does
+	Range(raw string) (time.Time, time.Time, error)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   []SegmentProvider
+)
+
+// RegisterSegment makes a custom segment available to all formats by its
+// name. The name can't clash with any other segment and can't have a `.` or
+// a `-` in it since those separate the parts of a version
+func RegisterSegment(p SegmentProvider) error {
+	name := p.Name()
+	if name == "" || strings.ContainsAny(name, ".-") {
+		return fmt.Errorf("invalid name for a custom segment: %s", name)
+	}
+
+	for _, v := range valid {
+		if v == name {
+			return fmt.Errorf("segment is already defined: %s", name)
+		}
+	}
+
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	for _, registered := range providers {
+		if registered.Name() == name {
+			return fmt.Errorf("segment is already defined: %s", name)
+		}
+	}
+
+	providers = append(providers, p)
+	return nil
+}
+
+// provider returns the custom segment provider behind the segment, if any
+func (s segment) provider() SegmentProvider {
+	if s < segmentCustom {
+		return nil
+	}
+
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	if i := int(s - segmentCustom); i < len(providers) {
+		return providers[i]
+	}
+
+	return nil
+}
+
+func customSegment(name string) (segment, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	for i, p := range providers {
+		if p.Name() == name {
+			return segmentCustom + segment(i), true
+		}
+	}
+
+	return segment(0), false
+}
+
+func customTokens() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	tokens := make([]string, len(providers))
+	for i, p := range providers {
+		tokens[i] = p.Name()
+	}
+
+	return tokens
+}
@@ -0,0 +1,134 @@
+package calver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var build = 41
+
+type buildSegment struct{}
+
+func (buildSegment) Name() string { return "BUILD" }
+
+func (buildSegment) Render(ctx Context) string { return strconv.Itoa(build) }
+
+func (buildSegment) Parse(raw string) (string, error) {
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 0 {
+		return "", fmt.Errorf("invalid build number: %s", raw)
+	}
+	return strconv.Itoa(v), nil
+}
+
+func (buildSegment) Compare(a, b string) int {
+	x, _ := strconv.Atoi(a)
+	y, _ := strconv.Atoi(b)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type trainSegment struct{}
+
+func (trainSegment) Name() string { return "codename" }
+
+func (trainSegment) Render(ctx Context) string {
+	return []string{"alder", "birch", "cedar", "dogwood"}[(int(ctx.Time.Month())-1)/3]
+}
+
+func (trainSegment) Parse(raw string) (string, error) {
+	if raw == "" || strings.Trim(raw, "abcdefghijklmnopqrstuvwxyz") != "" {
+		return "", fmt.Errorf("invalid codename: %s", raw)
+	}
+	return raw, nil
+}
+
+func (trainSegment) Compare(a, b string) int { return strings.Compare(a, b) }
+
+func init() {
+	if err := RegisterSegment(buildSegment{}); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterSegment(trainSegment{}); err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisterSegment(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2007, 5, 5, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	c, err := New("YYYY.0M.BUILD", "")
+	if err != nil {
+		t.Fatalf("failed to create a version: %s", err)
+	}
+
+	if c.String() != "YYYY.0M.BUILD" {
+		t.Errorf("empty version doesn't return the format")
+	}
+
+	if r := c.Release(); r != "2007.05.41" {
+		t.Errorf("release version should be 2007.05.41 but it was %s", r)
+	}
+
+	build++
+	defer func() { build-- }()
+
+	if r := c.Release(); r != "2007.05.42" {
+		t.Errorf("release version should be 2007.05.42 but it was %s", r)
+	}
+
+	n, err := New("YY.codename", "")
+	if err != nil {
+		t.Fatalf("failed to create a version: %s", err)
+	}
+
+	if r := n.PreRelease(); r != "7.birch-dev" {
+		t.Errorf("prerelease version should be 7.birch-dev but it was %s", r)
+	}
+
+	p, err := Parse("7.cedar-3", "YY.codename", "")
+	if err != nil {
+		t.Fatalf("failed to parse the version: %s", err)
+	}
+
+	if p.String() != "7.cedar-3" {
+		t.Errorf("failed to parse the version, expected 7.cedar-3 but got %s", p)
+	}
+
+	if _, err := Parse("7.Cedar", "YY.codename", ""); err == nil {
+		t.Error("invalid codename should not be parsed")
+	}
+
+	if _, err := p.GoModule(0); err == nil {
+		t.Error("custom segments should not be a part of a go module tag")
+	}
+
+	for _, s := range []SegmentProvider{buildSegment{}, namedSegment("YYYY"), namedSegment("A.B"), namedSegment("")} {
+		if err := RegisterSegment(s); err == nil {
+			t.Errorf("segment %s should not be registered", s.Name())
+		}
+	}
+}
+
+type namedSegment string
+
+func (s namedSegment) Name() string { return string(s) }
+
+func (namedSegment) Render(ctx Context) string { return "" }
+
+func (namedSegment) Parse(raw string) (string, error) { return raw, nil }
+
+func (namedSegment) Compare(a, b string) int { return strings.Compare(a, b) }
@@ -0,0 +1,144 @@
+package calver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cadence tells if a release happens on the provided day
+type Cadence func(day time.Time) bool
+
+// Daily is a cadence with a release every day
+func Daily() Cadence {
+	return func(day time.Time) bool {
+		return true
+	}
+}
+
+// Weekly is a cadence with a release on the provided day of every week
+func Weekly(weekday time.Weekday) Cadence {
+	return func(day time.Time) bool {
+		return day.Weekday() == weekday
+	}
+}
+
+// Monthly is a cadence with a release on the nth provided day of every
+// month, for instance the first Tuesday. A negative n counts from the end of
+// the month, so -1 is the last one
+func Monthly(n int, weekday time.Weekday) Cadence {
+	return func(day time.Time) bool {
+		if day.Weekday() != weekday {
+			return false
+		}
+
+		if n < 0 {
+			return day.AddDate(0, 0, -7*(n+1)).Month() == day.Month() && day.AddDate(0, 0, -7*n).Month() != day.Month()
+		}
+
+		return (day.Day()-1)/7 == n-1
+	}
+}
+
+// Quarterly is a cadence with a release on the first day of every quarter
+func Quarterly() Cadence {
+	return func(day time.Time) bool {
+		return day.Day() == 1 && (day.Month()-1)%3 == 0
+	}
+}
+
+var ordinals = map[string]int{
+	"first":  1,
+	"second": 2,
+	"third":  3,
+	"fourth": 4,
+	"last":   -1,
+}
+
+// ParseCadence returns the cadence for a name, which is either `daily`,
+// `quarterly`, a day of the week like `monday` or an ordinal along with a day
+// of the week like `first-tuesday` or `last-friday`
+func ParseCadence(name string) (Cadence, error) {
+	name = strings.ToLower(name)
+
+	switch name {
+	case "daily":
+		return Daily(), nil
+	case "quarterly":
+		return Quarterly(), nil
+	}
+
+	weekday := func(raw string) (time.Weekday, bool) {
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.ToLower(d.String()) == raw {
+				return d, true
+			}
+		}
+
+		return 0, false
+	}
+
+	if d, ok := weekday(name); ok {
+		return Weekly(d), nil
+	}
+
+	if parts := strings.SplitN(name, "-", 2); len(parts) == 2 {
+		n, ok := ordinals[parts[0]]
+		if d, found := weekday(parts[1]); ok && found {
+			return Monthly(n, d), nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid cadence: %s", name)
+}
+
+// Planned is a release that a schedule forecasts
+type Planned struct {
+	// Date is the day of the release
+	Date time.Time
+	// Version is what Release would return on that day
+	Version *CalVer
+}
+
+// Schedule lists the releases the format would have from one day until
+// another, for a release on each day of the cadence, for instance every
+// Monday of the next quarter:
+//		Schedule("YYYY.0M.0D", Weekly(time.Monday), from, from.AddDate(0, 3, 0))
+// The versions are the ones Release would return on those days, so a few
+// releases in the same period get an iteration each
+func Schedule(format string, cadence Cadence, from, to time.Time, opts ...Option) ([]Planned, error) {
+	c, err := New(format, "", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Schedule(cadence, from, to)
+}
+
+// Schedule lists the releases following the version from one day until
+// another, for a release on each day of the cadence, see Schedule
+func (c *CalVer) Schedule(cadence Cadence, from, to time.Time) ([]Planned, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("period ends before it starts: %s - %s", from, to)
+	}
+
+	n := *c
+
+	var planned []Planned
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !cadence(day) {
+			continue
+		}
+
+		n.release(day)
+		if err := n.Err(); err != nil {
+			return nil, err
+		}
+
+		v := n
+		planned = append(planned, Planned{Date: day, Version: &v})
+	}
+
+	return planned, nil
+}
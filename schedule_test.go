@@ -0,0 +1,89 @@
+package calver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	from := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		format   string
+		cadence  string
+		expected []string
+	}{
+		{"YYYY.0M.0D", "first-tuesday", []string{"2021.04.06", "2021.05.04", "2021.06.01"}},
+		{"YYYY.0M.0D", "last-friday", []string{"2021.04.30", "2021.05.28", "2021.06.25"}},
+		{"YYYY.0M", "monday", []string{
+			"2021.04", "2021.04-1", "2021.04-2", "2021.04-3",
+			"2021.05", "2021.05-1", "2021.05-2", "2021.05-3", "2021.05-4",
+			"2021.06", "2021.06-1", "2021.06-2", "2021.06-3",
+		}},
+		{"YY.Q", "quarterly", []string{"21.2"}},
+		{"YYYY.WW", "thursday", []string{
+			"2021.13", "2021.14", "2021.15", "2021.16", "2021.17", "2021.18", "2021.19",
+			"2021.20", "2021.21", "2021.22", "2021.23", "2021.24", "2021.25",
+		}},
+	}
+
+	for _, tc := range cases {
+		cadence, err := ParseCadence(tc.cadence)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		planned, err := Schedule(tc.format, cadence, from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(planned) != len(tc.expected) {
+			t.Errorf("releases for %s should be %d but they were %d", tc.cadence, len(tc.expected), len(planned))
+			continue
+		}
+
+		for i, p := range planned {
+			if p.Version.String() != tc.expected[i] {
+				t.Errorf("planned version should be %s but it was %s", tc.expected[i], p.Version)
+			}
+
+			if !cadence(p.Date) {
+				t.Errorf("planned day for %s should not be %s", tc.cadence, p.Date)
+			}
+		}
+	}
+}
+
+func TestSchedule_Release(t *testing.T) {
+	day := time.Date(2021, 4, 6, 0, 0, 0, 0, time.UTC)
+	reset := mockNowFunc(func() time.Time {
+		return day
+	})
+	defer reset()
+
+	c, _ := Parse("2021.04-2", "YYYY.0M", "dev")
+	planned, err := c.Schedule(Weekly(time.Tuesday), day, day.AddDate(0, 0, 1))
+	if err != nil || len(planned) != 1 {
+		t.Fatalf("schedule should be a single release but it was %v (%v)", planned, err)
+	}
+
+	if r := c.Release(); planned[0].Version.String() != r {
+		t.Errorf("planned version should be %s but it was %s", r, planned[0].Version)
+	}
+}
+
+func TestParseCadence(t *testing.T) {
+	for _, name := range []string{"daily", "quarterly", "Monday", "second-wednesday", "fourth-sunday"} {
+		if _, err := ParseCadence(name); err != nil {
+			t.Errorf("failed to parse cadence %s: %s", name, err)
+		}
+	}
+
+	for _, name := range []string{"", "fortnightly", "fifth-monday", "first-day"} {
+		if _, err := ParseCadence(name); err == nil {
+			t.Errorf("invalid cadence %s should not be parsed", name)
+		}
+	}
+}
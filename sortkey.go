@@ -0,0 +1,78 @@
+package calver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keyWidth returns the number of digits a segment takes in a sort key, which
+// is enough for the highest value the segment could hold
+func (s segment) keyWidth() int {
+	switch s {
+	case segmentFullYear, segmentShortYear, segmentPaddedYear:
+		return 4
+	case segmentShortDayOfYear, segmentPaddedDayOfYear:
+		return 3
+	case segmentQuarter:
+		return 1
+	case segmentMicro:
+		return 20
+	default:
+		if s.provider() != nil {
+			return 20
+		}
+		return 2
+	}
+}
+
+// SortKey returns the version encoded in a way that sorting the keys of a
+// format byte by byte sorts the versions in the order they were released,
+// which is handy for stores that only sort lexically, for instance:
+//		2021.3.5-dev	->	2021.03.05.00000000000000000000.0
+//		2021.3.5		->	2021.03.05.00000000000000000000.1
+//		2021.3.5-dev.1	->	2021.03.05.00000000000000000001.0
+//		2021.3.5-1		->	2021.03.05.00000000000000000001.1
+// Each numeric segment is padded to a fixed width so keys of the same format
+// always have the same length, except for custom segments with values that
+// aren't numbers, which are kept as they are and sort by their bytes
+func (c *CalVer) SortKey() string {
+	if c.major == "" {
+		return ""
+	}
+
+	var fields []string
+	for i, raw := range strings.Split(c.core(), ".") {
+		p := c.format.parts()[i]
+
+		vals, err := p.split(raw)
+		if err != nil {
+			vals = []string{raw}
+		}
+
+		for j, v := range vals {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil || j >= len(p) {
+				fields = append(fields, v)
+				continue
+			}
+
+			fields = append(fields, fmt.Sprintf("%0*d", p[j].keyWidth(), n))
+		}
+	}
+
+	// a prerelease comes right before the release with the same iteration
+	rank := "1"
+	if c.pre {
+		rank = "0"
+	}
+
+	fields = append(fields, fmt.Sprintf("%020d", c.iteration()), rank)
+
+	return strings.Join(fields, ".")
+}
+
+// SortKeyBytes is the same as SortKey but returns the key as bytes
+func (c *CalVer) SortKeyBytes() []byte {
+	return []byte(c.SortKey())
+}
@@ -0,0 +1,79 @@
+package calver
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCalVer_SortKey(t *testing.T) {
+	for _, tc := range []struct {
+		format   string
+		versions []string
+	}{
+		{"YYYY.MM.DD", []string{
+			"2021.3.5-dev",
+			"2021.3.5",
+			"2021.3.5-dev.1",
+			"2021.3.5-1",
+			"2021.3.5-2",
+			"2021.3.5-dev.9",
+			"2021.3.5-9",
+			"2021.3.5-10",
+			"2021.3.15",
+			"2021.10.1-dev",
+			"2021.10.1",
+			"2022.1.1",
+		}},
+		{"YY.0M.MICRO", []string{
+			"9.12.0",
+			"21.03.0",
+			"21.03.1-dev",
+			"21.03.1",
+			"21.03.10",
+			"121.01.0",
+		}},
+		{"YYYY.0H0m", []string{
+			"2021.0959",
+			"2021.1000-dev",
+			"2021.1000",
+			"2021.1000-1",
+		}},
+	} {
+		var prev []byte
+		for i, raw := range tc.versions {
+			c, err := Parse(raw, tc.format, "dev")
+			if err != nil {
+				t.Fatalf("failed to parse %s: %s", raw, err)
+			}
+
+			key := c.SortKeyBytes()
+			if string(key) != c.SortKey() {
+				t.Errorf("sort keys of %s should be the same", raw)
+			}
+
+			if i > 0 && bytes.Compare(prev, key) >= 0 {
+				t.Errorf("sort key of %s should be before %s but it was %s and %s", tc.versions[i-1], raw, prev, key)
+			}
+
+			if i > 0 && len(prev) != len(key) {
+				t.Errorf("sort keys should be of the same length but they were %s and %s", prev, key)
+			}
+
+			prev = key
+		}
+	}
+}
+
+func TestCalVer_SortKeyEmpty(t *testing.T) {
+	c, _ := New("YYYY.MM.DD", "dev")
+	if c.SortKey() != "" {
+		t.Errorf("sort key should be empty but it was %s", c.SortKey())
+	}
+}
+
+func TestCalVer_SortKeyExample(t *testing.T) {
+	c, _ := Parse("2021.3.5-dev.1", "YYYY.MM.DD", "dev")
+	if expected := "2021.03.05.00000000000000000001.0"; c.SortKey() != expected {
+		t.Errorf("sort key should be %s but it was %s", expected, c.SortKey())
+	}
+}
@@ -0,0 +1,56 @@
+package calver
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithStrict is an option to reject versions that stand for dates that don't
+// exist, like `2021.2.30` in `YYYY.MM.DD`, or that are yet to come. Formats
+// that can't be told a date, since they don't have a year segment, are only
+// checked segment by segment as usual
+func WithStrict() Option {
+	return func(c *CalVer) error {
+		c.strict = true
+		return nil
+	}
+}
+
+// dated tells if the format could tell the dates of its versions
+func (f *format) dated() bool {
+	if f.has(segmentFullYear, segmentShortYear, segmentPaddedYear) {
+		return true
+	}
+
+	for _, p := range f.parts() {
+		for _, s := range p {
+			if _, ok := s.provider().(SegmentRange); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// check makes sure the version stands for a date that exists and has already
+// come
+func (c *CalVer) check() error {
+	if !c.format.dated() {
+		return nil
+	}
+
+	dates, _, err := c.dates()
+	if err != nil {
+		return fmt.Errorf("provided string isn't a valid date for the format: %s", c.format)
+	}
+
+	// versions are released in the zone of the clock, so the date is told in
+	// that zone too rather than in UTC
+	t, d := now(), dates[0]
+	if start := time.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute(), d.Second(), 0, t.Location()); start.After(t) {
+		return fmt.Errorf("provided string is a date in the future: %s", d.Format("2006-01-02"))
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package calver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Strict(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2021, 3, 5, 12, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	valid := map[string]string{
+		"2021.3.5":     "YYYY.MM.DD",
+		"2021.2.28-1":  "YYYY.MM.DD",
+		"2020.2.29":    "YYYY.MM.DD",
+		"21.03":        "YY.0M",
+		"3.5":          "MM.DD",
+		"2021.0305.12": "YYYY.0M0D.0H",
+	}
+
+	for raw, format := range valid {
+		if _, err := Parse(raw, format, "dev", WithStrict()); err != nil {
+			t.Errorf("failed to parse %s: %s", raw, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"2021.2.29":    "YYYY.MM.DD",
+		"2021.4.31":    "YYYY.MM.DD",
+		"2021.3.6":     "YYYY.MM.DD",
+		"22.01":        "YY.0M",
+		"2021.0305.13": "YYYY.0M0D.0H",
+	}
+
+	for raw, format := range invalid {
+		if _, err := Parse(raw, format, "dev", WithStrict()); err == nil {
+			t.Errorf("invalid version %s should not be parsed", raw)
+		}
+
+		if _, err := Parse(raw, format, "dev"); err != nil {
+			t.Errorf("failed to parse %s without being strict: %s", raw, err)
+		}
+	}
+}
+
+func TestParse_StrictZone(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2021, 3, 5, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	})
+	defer reset()
+
+	c, _ := New("YYYY.MM.DD", "dev")
+	r := c.Release()
+	if r != "2021.3.5" {
+		t.Errorf("release version should be 2021.3.5 but it was %s", r)
+	}
+
+	if _, err := Parse(r, "YYYY.MM.DD", "dev", WithStrict()); err != nil {
+		t.Errorf("failed to parse %s: %s", r, err)
+	}
+
+	if _, err := Parse("2021.3.6", "YYYY.MM.DD", "dev", WithStrict()); err == nil {
+		t.Error("invalid version 2021.3.6 should not be parsed")
+	}
+
+	c, _ = New("YYYY.0M0D.0H", "dev")
+	if _, err := Parse(c.Release(), "YYYY.0M0D.0H", "dev", WithStrict()); err != nil {
+		t.Errorf("failed to parse %s: %s", c, err)
+	}
+}
@@ -0,0 +1,141 @@
+package calver
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Train is a custom segment that counts the release trains, i.e. the periods
+// of a fixed length, elapsed since an epoch. It's meant to be registered with
+// RegisterSegment, for instance a two-week sprint starting on 7th of January,
+// 2019 would be:
+//		calver.RegisterSegment(calver.Train{
+//			Epoch: time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC),
+//			Days:  14,
+//		})
+// which makes `TRAIN.MICRO` give 56.0 on 5th of March, 2021
+type Train struct {
+	// Token is the name of the segment in formats, `TRAIN` by default
+	Token string
+	// Epoch is when the first train starts, there can't be any release
+	// before it
+	Epoch time.Time
+	// Days is the length of a train in days, unless Months is set. It's a
+	// week if neither of them is set
+	Days int
+	// Months is the length of a train in months. Trains start on the day of
+	// the month of the epoch, or on the last day of months that are shorter
+	Months int
+	// Offset is added to the number of trains, for instance to count them
+	// from 1 rather than 0
+	Offset int
+}
+
+// Name implements SegmentProvider
+func (tr Train) Name() string {
+	if tr.Token == "" {
+		return "TRAIN"
+	}
+
+	return tr.Token
+}
+
+// Render implements SegmentProvider
+func (tr Train) Render(ctx Context) string {
+	return strconv.Itoa(tr.Number(ctx.Time))
+}
+
+// Parse implements SegmentProvider
+func (tr Train) Parse(raw string) (string, error) {
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < tr.Offset || strconv.Itoa(n) != raw {
+		return "", fmt.Errorf("invalid release train: %s", raw)
+	}
+
+	return raw, nil
+}
+
+// Compare implements SegmentProvider
+func (tr Train) Compare(a, b string) int {
+	x, _ := strconv.Atoi(a)
+	y, _ := strconv.Atoi(b)
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (tr Train) epoch() time.Time {
+	return time.Date(tr.Epoch.Year(), tr.Epoch.Month(), tr.Epoch.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (tr Train) days() int {
+	if tr.Days <= 0 {
+		return 7
+	}
+
+	return tr.Days
+}
+
+// start returns the first day of the nth train counted from the epoch
+func (tr Train) start(n int) time.Time {
+	epoch := tr.epoch()
+	if tr.Months <= 0 {
+		return epoch.AddDate(0, 0, n*tr.days())
+	}
+
+	month := time.Date(epoch.Year(), epoch.Month()+time.Month(n*tr.Months), 1, 0, 0, 0, 0, time.UTC)
+	day := epoch.Day()
+	if last := month.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+
+	return month.AddDate(0, 0, day-1)
+}
+
+// Number returns the train the provided time falls in, times before the epoch
+// are counted as the first train although they can't be released
+func (tr Train) Number(t time.Time) int {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	epoch := tr.epoch()
+
+	if day.Before(epoch) {
+		return tr.Offset
+	}
+
+	if tr.Months <= 0 {
+		return tr.Offset + int(day.Sub(epoch).Hours()/24)/tr.days()
+	}
+
+	// the months in between are a close guess, which is off by one when the
+	// train starts later in the month than the day
+	n := ((day.Year()-epoch.Year())*12 + int(day.Month()) - int(epoch.Month())) / tr.Months
+	for n > 0 && tr.start(n).After(day) {
+		n--
+	}
+
+	for !tr.start(n + 1).After(day) {
+		n++
+	}
+
+	return tr.Offset + n
+}
+
+// Range implements SegmentRange, it returns the first day of the provided
+// train and the first day of the one after it
+func (tr Train) Range(raw string) (time.Time, time.Time, error) {
+	if _, err := tr.Parse(raw); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	n, _ := strconv.Atoi(raw)
+	n -= tr.Offset
+
+	return tr.start(n), tr.start(n + 1), nil
+}
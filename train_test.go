@@ -0,0 +1,137 @@
+package calver
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+var (
+	sprint = Train{
+		Token: "SPRINT",
+		Epoch: time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC),
+		Days:  14,
+	}
+	monthly = Train{
+		Token:  "MONTHLY",
+		Epoch:  time.Date(2019, 1, 15, 0, 0, 0, 0, time.UTC),
+		Months: 1,
+		Offset: 1,
+	}
+)
+
+func init() {
+	if err := RegisterSegment(sprint); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterSegment(monthly); err != nil {
+		panic(err)
+	}
+}
+
+func TestTrain(t *testing.T) {
+	cases := []struct {
+		now      time.Time
+		format   string
+		expected string
+	}{
+		{time.Date(2021, 3, 5, 10, 0, 0, 0, time.UTC), "SPRINT.MICRO", "56.0"},
+		{time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC), "SPRINT.MICRO", "0.0"},
+		{time.Date(2019, 1, 20, 0, 0, 0, 0, time.UTC), "SPRINT.MICRO", "0.0"},
+		{time.Date(2019, 1, 21, 0, 0, 0, 0, time.UTC), "SPRINT.MICRO", "1.0"},
+		{time.Date(2019, 2, 14, 0, 0, 0, 0, time.UTC), "MONTHLY.MICRO", "1.0"},
+		{time.Date(2019, 2, 15, 0, 0, 0, 0, time.UTC), "MONTHLY.MICRO", "2.0"},
+		{time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC), "YYYY.MONTHLY", "2021.26"},
+	}
+
+	for _, tc := range cases {
+		reset := mockNowFunc(func() time.Time {
+			return tc.now
+		})
+
+		c, err := New(tc.format, "")
+		if err != nil {
+			t.Fatalf("failed to create a version: %s", err)
+		}
+
+		if r := c.Release(); r != tc.expected {
+			t.Errorf("release version on %s should be %s but it was %s", tc.now.Format("2006-01-02"), tc.expected, r)
+		}
+
+		reset()
+	}
+}
+
+func TestTrain_BeforeEpoch(t *testing.T) {
+	reset := mockNowFunc(func() time.Time {
+		return time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC)
+	})
+	defer reset()
+
+	c, _ := New("SPRINT.MICRO", "")
+	if c.Release(); c.Err() == nil {
+		t.Errorf("release before the epoch should not be made but it was %s", c)
+	}
+}
+
+func TestTrain_EndOfMonth(t *testing.T) {
+	tr := Train{Epoch: time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC), Months: 1}
+
+	for day, expected := range map[string]int{
+		"2019-01-31": 0,
+		"2019-02-27": 0,
+		"2019-02-28": 1,
+		"2019-03-01": 1,
+		"2019-03-30": 1,
+		"2019-03-31": 2,
+		"2019-04-30": 3,
+		"2020-02-29": 13,
+	} {
+		d, _ := time.Parse("2006-01-02", day)
+		n := tr.Number(d)
+		if n != expected {
+			t.Errorf("train on %s should be %d but it was %d", day, expected, n)
+		}
+
+		from, to, err := tr.Range(strconv.Itoa(n))
+		if err != nil || d.Before(from) || !d.Before(to) {
+			t.Errorf("range of train %d should have %s but it was from %s to %s (%v)", n, day, from, to, err)
+		}
+	}
+}
+
+func TestTrain_Range(t *testing.T) {
+	from, to, err := sprint.Range("56")
+	if err != nil {
+		t.Fatalf("failed to get the range of the train: %s", err)
+	}
+
+	if !from.Equal(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("train 56 should be from 2021-03-01 to 2021-03-15 but it was from %s to %s", from, to)
+	}
+
+	if _, _, err := monthly.Range("0"); err == nil {
+		t.Error("train before the offset should not have a range")
+	}
+
+	c, _ := Parse("56.0", "SPRINT.MICRO", "")
+	n, err := c.Convert("YYYY.0W")
+	if err == nil {
+		t.Errorf("train spanning two weeks should not be converted into a week but got %s", n)
+	}
+
+	n, err = c.Convert("YYYY.MM")
+	if err != nil {
+		t.Fatalf("failed to convert %s: %s", c, err)
+	}
+
+	if n.String() != "2021.3" {
+		t.Errorf("converted version of %s should be 2021.3 but it was %s", c, n)
+	}
+
+	c, _ = Parse("56.3", "SPRINT.MICRO", "")
+	if n, err := c.Convert("YYYY.MM"); err == nil {
+		t.Errorf("iteration of %s should not be kept in a month but got %s", c, n)
+	}
+}